@@ -2,19 +2,43 @@ package app
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
+	"sync"
+	"time"
 )
 
+// DefaultShutdownTimeout bounds a shutdown hook registered without its own timeout.
+const DefaultShutdownTimeout = 10 * time.Second
+
 type appConfig struct {
-	Title       string `koanf:"title"`
-	Version     string `koanf:"version"`
-	Description string `koanf:"description"`
-	Environment string `koanf:"environment"`
+	Title           string        `koanf:"title"            doc:"required"`
+	Version         string        `koanf:"version"`
+	Description     string        `koanf:"description"`
+	Environment     string        `koanf:"environment"`
+	ShutdownTimeout time.Duration `koanf:"shutdown_timeout" doc:"default deadline of each shutdown hook, 0 waits without one"`
+	RestartTimeout  time.Duration `koanf:"restart_timeout"  doc:"how long SIGUSR2 waits for the new binary to become ready"`
+}
+
+func (c *appConfig) SetDefaults() {
+	c.ShutdownTimeout = DefaultShutdownTimeout
+	c.RestartTimeout = DefaultRestartTimeout
+}
+
+func (c *appConfig) Validate() error {
+	return errors.Join(
+		Required("title", c.Title),
+		NonNegative("shutdown_timeout", c.ShutdownTimeout),
+		NonNegative("restart_timeout", c.RestartTimeout),
+	)
 }
 
 func NewAppConfig(ctx context.Context, c *KConfig) (*appConfig, error) {
 	config := new(appConfig)
+	config.SetDefaults()
+
 	if err := c.Unmarshal("app", config); err != nil {
 		return nil, err
 	}
@@ -25,6 +49,13 @@ func NewAppConfig(ctx context.Context, c *KConfig) (*appConfig, error) {
 type Application interface {
 	Start(ctx context.Context) error
 	Shutdown(ctx context.Context) error
+	// Restart hands the listeners to a new instance of the binary and waits
+	// until it is ready; the caller then shuts this instance down.
+	Restart(ctx context.Context) error
+	// ReloadConfig reloads the config, keeping the current one when the new one is invalid.
+	ReloadConfig(ctx context.Context) error
+	// Errors delivers fatal errors raised after Start returned.
+	Errors() <-chan error
 	GetLogger() *slog.Logger
 }
 
@@ -34,69 +65,243 @@ type app struct {
 	logger      *slog.Logger
 	httpHandler *http.ServeMux
 
-	appConfig *appConfig
+	config     *KConfig
+	appConfig  *appConfig
+	httpConfig *httpServerConfig
 
-	httpServer HTTPServer
-	appLogger  AppLogger
-	controller Controller
+	httpServers HTTPServers
+	appLogger   AppLogger
+	controller  Controller
+	errCh       chan error
 }
 
 func NewApp(
-	run *runTimeFlags,
+	run *RunTimeFlags,
+	config *KConfig,
 	appConfig *appConfig,
-	httpServer HTTPServer,
+	httpConfig *httpServerConfig,
+	httpServers HTTPServers,
 	appLogger AppLogger,
 	controller Controller,
 ) *app {
 	a := &app{
-		appConfig:  appConfig,
-		logger:     appLogger.GetLogger().With("component", "app"),
-		httpServer: httpServer,
-		appLogger:  appLogger,
-		controller: controller,
+		config:      config,
+		appConfig:   appConfig,
+		httpConfig:  httpConfig,
+		logger:      appLogger.GetLogger().With("component", "app"),
+		httpServers: httpServers,
+		appLogger:   appLogger,
+		controller:  controller,
+		errCh:       make(chan error, len(httpServers)),
+	}
+
+	controller.RegisterStartup("config", func(ctx context.Context) error {
+		return config.Watch(ctx, a.logger)
+	})
+	controller.RegisterShutdown("config", config.Unwatch)
+
+	for _, s := range httpServers {
+		go func(s HTTPServer) {
+			for err := range s.Errors() {
+				a.errCh <- err
+			}
+		}(s)
 	}
 
 	return a
 }
 
+// Start validates the lifecycle order of every registered component, so a
+// missing dependency or a cycle fails before anything starts, then runs the
+// startup hooks in dependency order.
 func (a *app) Start(ctx context.Context) error {
 	logger := a.logger.With("component", "app", "version", a.appConfig.Version)
 
-	if err := a.httpServer.Start(ctx); err != nil {
-		logger.Error("Failed to start HTTP server", "error", err)
+	if err := a.controller.Validate(); err != nil {
+		logger.Error("Invalid component lifecycle order", "error", err)
 
 		return err
 	}
 
-	for name, startup := range a.controller.GetStarters() {
-		logger.Info("Starting component...", "component", name)
+	logger.Info("Config loaded, later sources override earlier ones",
+		"sources", a.config.Sources(), "profile", a.config.Profile(), "env_prefix", "APP_", "env_separator", "__ when a key has underscores, else _")
+	warnUnknownKeys(ctx, logger, a.config)
 
-		if err := startup(ctx); err != nil {
-			logger.Error("Failed to start component", "component", name, "error", err)
+	starters, err := a.controller.GetStarters()
+	if err != nil {
+		logger.Error("Invalid component lifecycle order", "error", err)
+
+		return err
+	}
+
+	for _, starter := range starters {
+		logger.Info("Starting component...", "component", starter.Name)
+
+		if err := runHook(ctx, starter, 0); err != nil {
+			logger.Error("Failed to start component", "component", starter.Name, "error", err)
 
 			return err
 		}
 	}
 
+	a.controller.MarkStarted()
+	a.notifyParentWhenReady(ctx)
+
 	return nil
 }
 
+func (a *app) ReloadConfig(ctx context.Context) error {
+	if err := a.config.Reload(ctx); err != nil {
+		a.logger.Error("Config reload rejected, keeping the current config", "error", err)
+
+		return err
+	}
+
+	a.logger.Info("Config reloaded")
+	warnUnknownKeys(ctx, a.logger, a.config)
+
+	return nil
+}
+
+// Shutdown marks a started application as draining so readiness fails, waits
+// for the configured drain delay, then runs every shutdown hook. HTTP servers
+// shut down first, waiting for in-flight requests. Hooks that do not depend
+// on each other run concurrently, each under its own deadline.
+// The returned error joins every failure, naming the component that failed.
 func (a *app) Shutdown(ctx context.Context) error {
 	a.logger.Info("Shutting down application...")
 
-	if err := a.httpServer.Shutdown(ctx); err != nil {
-		a.logger.Error("Failed to shutdown HTTP server", "error", err)
+	// An app that never started was never ready, so there is nothing to drain.
+	if a.controller.IsStarted() {
+		a.drain(ctx)
 	}
 
-	for name, shutdown := range a.controller.GetSutdowners() {
-		a.logger.Info("Shutting down component...", "component", name)
+	shutdowners, err := a.controller.GetShutdowners()
+	if err != nil {
+		a.logger.Error("Invalid component lifecycle order", "error", err)
 
-		if err := shutdown(ctx); err != nil {
-			a.logger.Error("Failed to shutdown component", "component", name, "error", err)
-		}
+		return err
 	}
 
-	return nil
+	return RunShutdowners(ctx, a.logger, shutdowners, a.appConfig.ShutdownTimeout)
+}
+
+// drain flips readiness to failing and gives load balancers drain_delay to
+// stop routing new requests here.
+func (a *app) drain(ctx context.Context) {
+	a.controller.Drain()
+
+	delay := a.httpConfig.HTTP.DrainDelay
+	if delay <= 0 {
+		return
+	}
+
+	a.logger.Info("Draining...", "delay", delay)
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+}
+
+// RunShutdowners runs hooks concurrently, starting each one once every hook
+// listed in its After has finished. A hook without a timeout gets defaultTimeout.
+// A hook still waiting when ctx is done is skipped and reported.
+func RunShutdowners(ctx context.Context, logger *slog.Logger, hooks []Hook, defaultTimeout time.Duration) error {
+	done := make(map[string]chan struct{}, len(hooks))
+	for _, hook := range hooks {
+		done[hook.Name] = make(chan struct{})
+	}
+
+	var (
+		mu   sync.Mutex
+		errs error
+		wg   sync.WaitGroup
+	)
+
+	for _, hook := range hooks {
+		wg.Add(1)
+
+		go func(hook Hook) {
+			defer wg.Done()
+			defer close(done[hook.Name])
+
+			for _, name := range hook.After {
+				ch, ok := done[name]
+				if !ok {
+					continue
+				}
+
+				select {
+				case <-ch:
+				case <-ctx.Done():
+					logger.Error("Gave up waiting to shut down component", "component", hook.Name, "waiting_for", name)
+
+					mu.Lock()
+					errs = errors.Join(errs, fmt.Errorf("shutdown %s: waiting for %s: %w", hook.Name, name, ctx.Err()))
+					mu.Unlock()
+
+					return
+				}
+			}
+
+			logger.Info("Shutting down component...", "component", hook.Name)
+
+			start := time.Now()
+
+			if err := runHook(ctx, hook, defaultTimeout); err != nil {
+				logger.Error("Failed to shutdown component", "component", hook.Name, "error", err)
+
+				mu.Lock()
+				errs = errors.Join(errs, fmt.Errorf("shutdown %s: %w", hook.Name, err))
+				mu.Unlock()
+
+				return
+			}
+
+			logger.Info("Component shut down", "component", hook.Name, "duration", time.Since(start))
+		}(hook)
+	}
+
+	wg.Wait()
+
+	return errs
+}
+
+// runHook calls hook.Func under the hook's timeout, or defaultTimeout when
+// the hook has none. A zero defaultTimeout leaves the context unbounded.
+func runHook(ctx context.Context, hook Hook, defaultTimeout time.Duration) error {
+	timeout := hook.Timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+
+	if timeout <= 0 {
+		return hook.Func(ctx)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	errCh := make(chan error, 1)
+
+	go func() {
+		errCh <- hook.Func(ctx)
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (a *app) Errors() <-chan error {
+	return a.errCh
 }
 
 func (a *app) GetLogger() *slog.Logger {
@@ -0,0 +1,170 @@
+package app_test
+
+import (
+	"application/app"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/knadh/koanf/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunShutdownersOrderAndConcurrency(t *testing.T) {
+	c := app.NewController()
+
+	var (
+		mu       sync.Mutex
+		finished []string
+	)
+
+	record := func(name string, d time.Duration) func(context.Context) error {
+		return func(context.Context) error {
+			time.Sleep(d)
+			mu.Lock()
+			finished = append(finished, name)
+			mu.Unlock()
+
+			return nil
+		}
+	}
+
+	c.RegisterShutdown("otlp", record("otlp", 0))
+	c.RegisterShutdown("pgx", record("pgx", 50*time.Millisecond), app.WithDependsOn("otlp"))
+	c.RegisterShutdown("cache", record("cache", 50*time.Millisecond), app.WithDependsOn("otlp"))
+
+	hooks, err := c.GetShutdowners()
+	require.NoError(t, err)
+
+	start := time.Now()
+	require.NoError(t, app.RunShutdowners(context.Background(), slog.New(slog.NewTextHandler(io.Discard, nil)), hooks, time.Second))
+
+	assert.Less(t, time.Since(start), 90*time.Millisecond, "independent hooks should run concurrently")
+	require.Len(t, finished, 3)
+	assert.Equal(t, "otlp", finished[2])
+}
+
+func TestRunShutdownersTimeoutAndErrors(t *testing.T) {
+	c := app.NewController()
+	errBoom := errors.New("boom")
+
+	c.RegisterShutdown("slow", func(ctx context.Context) error {
+		time.Sleep(time.Second)
+
+		return nil
+	}, app.WithHookTimeout(20*time.Millisecond))
+	c.RegisterShutdown("broken", func(context.Context) error { return errBoom })
+	c.RegisterShutdown("fine", func(context.Context) error { return nil })
+
+	hooks, err := c.GetShutdowners()
+	require.NoError(t, err)
+
+	err = app.RunShutdowners(context.Background(), slog.New(slog.NewTextHandler(io.Discard, nil)), hooks, time.Second)
+	require.Error(t, err)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.ErrorIs(t, err, errBoom)
+	assert.Contains(t, err.Error(), "shutdown slow")
+	assert.Contains(t, err.Error(), "shutdown broken")
+	assert.NotContains(t, err.Error(), "fine")
+}
+
+func TestRunShutdownersContextDone(t *testing.T) {
+	hooks := []app.Hook{
+		{Name: "http", Func: func(ctx context.Context) error {
+			<-ctx.Done()
+
+			return ctx.Err()
+		}},
+		{Name: "pool", Func: func(context.Context) error { return nil }, After: []string{"http"}},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	err := app.RunShutdowners(ctx, slog.New(slog.NewTextHandler(io.Discard, nil)), hooks, 0)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Contains(t, err.Error(), "shutdown pool: waiting for http")
+}
+
+func TestAppStartValidatesLifecycleOrder(t *testing.T) {
+	a, controller, servers := newRestartApp(t, nil, nil)
+
+	started := false
+	controller.RegisterStartup("cache", func(context.Context) error {
+		started = true
+
+		return nil
+	})
+	controller.RegisterShutdown("pgx", func(context.Context) error { return nil }, app.WithDependsOn("missing"))
+
+	require.ErrorIs(t, a.Start(context.Background()), app.ErrHookMissingDependency)
+	assert.False(t, started, "nothing starts when the order is invalid")
+	assert.Nil(t, servers[0].Addr())
+}
+
+func TestAppConfigShutdownTimeout(t *testing.T) {
+	ctx := context.Background()
+
+	for value, want := range map[any]time.Duration{
+		nil:  app.DefaultShutdownTimeout,
+		"0s": 0,
+		"3s": 3 * time.Second,
+	} {
+		k := koanf.New(".")
+		require.NoError(t, k.Set("app.title", "app"))
+
+		if value != nil {
+			require.NoError(t, k.Set("app.shutdown_timeout", value))
+		}
+
+		cfg, err := app.NewAppConfig(ctx, app.NewKConfig(k))
+		require.NoError(t, err)
+		assert.Equal(t, want, app.ShutdownTimeout(cfg), "shutdown_timeout %v", value)
+	}
+}
+
+func TestAppShutdownDrains(t *testing.T) {
+	const delay = 100 * time.Millisecond
+
+	a, controller, _ := newRestartApp(t, map[string]any{"server.http.drain_delay": delay.String()}, nil)
+
+	var (
+		start        time.Time
+		stoppedAfter time.Duration
+	)
+
+	controller.RegisterShutdown("cache", func(context.Context) error {
+		stoppedAfter = time.Since(start)
+
+		return nil
+	})
+	require.NoError(t, a.Start(context.Background()))
+
+	done := make(chan error, 1)
+
+	start = time.Now()
+
+	go func() { done <- a.Shutdown(context.Background()) }()
+
+	require.Eventually(t, controller.IsDraining, time.Second, time.Millisecond, "readiness fails right away")
+	require.NoError(t, <-done)
+	assert.GreaterOrEqual(t, stoppedAfter, delay, "hooks wait for drain_delay")
+}
+
+func TestAppShutdownSkipsDrainWhenNotStarted(t *testing.T) {
+	a, controller, _ := newRestartApp(t, map[string]any{"server.http.drain_delay": "10s"}, nil)
+
+	errStart := errors.New("start failed")
+	controller.RegisterStartup("cache", func(context.Context) error { return errStart })
+
+	require.ErrorIs(t, a.Start(context.Background()), errStart)
+
+	start := time.Now()
+	require.NoError(t, a.Shutdown(context.Background()))
+	assert.Less(t, time.Since(start), time.Second, "an app that never became ready does not drain")
+}
@@ -1,34 +1,367 @@
 package app
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"slices"
 	"strings"
+	"sync"
+	"sync/atomic"
 
 	"github.com/knadh/koanf/parsers/yaml"
 	"github.com/knadh/koanf/providers/env"
 	"github.com/knadh/koanf/providers/file"
 	"github.com/knadh/koanf/v2"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelmetricapi "go.opentelemetry.io/otel/metric"
 )
 
+// KConfig is the loaded configuration. Reload swaps the Koanf it reads from
+// atomically; components reading values after startup must Subscribe to
+// their keys.
 type KConfig struct {
-	*koanf.Koanf
+	k atomic.Pointer[koanf.Koanf]
+
+	paths   []string
+	profile string
+	// sources are the files merged by the last successful load, in order.
+	sources []string
+	files   []*file.File
+
+	// reloadMu serializes reloads; mu guards the fields below it and is
+	// never held while validators or subscribers run.
+	reloadMu    sync.Mutex
+	mu          sync.Mutex
+	validators  []func(k *koanf.Koanf) error
+	subscribers []configSubscriber
+
+	reloads otelmetricapi.Int64Counter
+}
+
+type configSubscriber struct {
+	key string
+	fn  func(ctx context.Context, c *KConfig)
 }
 
-var ErrFailedToLoadEnvVars = errors.New("failed to load env vars")
+var (
+	ErrFailedToLoadEnvVars = errors.New("failed to load env vars")
+	ErrInvalidConfig       = errors.New("invalid config")
+)
 
-func NewKoanfConfig(runtimeFlags *runTimeFlags) (*KConfig, error) {
-	k := koanf.New(".")
+// NewKoanfConfig merges the config files given by runtimeFlags, then the
+// APP_ env vars on top of them; see configSources for the order of the files.
+func NewKoanfConfig(runtimeFlags *RunTimeFlags) (*KConfig, error) {
+	c := &KConfig{
+		paths:   runtimeFlags.ConfigPaths,
+		profile: runtimeFlags.Profile,
+	}
 
-	if err := k.Load(file.Provider(runtimeFlags.configYamlAddress), yaml.Parser()); err != nil {
+	k, sources, err := c.load()
+	if err != nil {
 		return nil, err
 	}
 
+	c.sources = sources
+
+	if err := ValidateConfig(k); err != nil {
+		return nil, err
+	}
+
+	c.k.Store(k)
+
+	c.reloads, err = otel.Meter("application/app/config").Int64Counter("config.reloads",
+		otelmetricapi.WithDescription("Config reloads by result"))
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	return c, nil
+}
+
+// load resolves the config files again, so files added to a config directory
+// are picked up on reload, and merges them followed by the APP_ env vars.
+func (c *KConfig) load() (*koanf.Koanf, []string, error) {
+	sources, err := configSources(c.paths, c.profile)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	k := koanf.New(".")
+
+	for _, source := range sources {
+		if err := k.Load(file.Provider(source), yaml.Parser()); err != nil {
+			return nil, nil, fmt.Errorf("load %s: %w", source, err)
+		}
+	}
+
 	if err := k.Load(env.Provider("APP_", ".", func(s string) string {
-		return strings.ReplaceAll(strings.ToLower(
-			strings.TrimPrefix(s, "APP_")), "_", ".")
+		if s == EnvProfile {
+			return ""
+		}
+
+		return envKey(s)
 	}), nil); err != nil {
-		return nil, errors.Join(ErrFailedToLoadEnvVars, err)
+		return nil, nil, errors.Join(ErrFailedToLoadEnvVars, err)
+	}
+
+	return k, sources, nil
+}
+
+// envKey returns the config key an APP_ env var sets. A var containing "__"
+// separates the key segments with it, so keys with underscores can be set:
+// APP_APP__SHUTDOWN_TIMEOUT sets app.shutdown_timeout. Otherwise every "_"
+// separates segments, as in APP_APP_TITLE.
+func envKey(name string) string {
+	name = strings.ToLower(strings.TrimPrefix(name, "APP_"))
+
+	if strings.Contains(name, "__") {
+		return strings.ReplaceAll(name, "__", ".")
+	}
+
+	return strings.ReplaceAll(name, "_", ".")
+}
+
+// Sources returns the config files in the order they were merged; APP_ env
+// vars are applied after the last of them.
+func (c *KConfig) Sources() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return slices.Clone(c.sources)
+}
+
+// Profile returns the selected config profile, empty when none is.
+func (c *KConfig) Profile() string {
+	return c.profile
+}
+
+// NewKConfig wraps an already loaded k, for tests and tools; it is neither
+// validated nor reloadable.
+func NewKConfig(k *koanf.Koanf) *KConfig {
+	c := new(KConfig)
+	c.k.Store(k)
+
+	return c
+}
+
+// Koanf returns the current config. Reload replaces it rather than changing
+// it, so a caller reading several values from it sees a consistent config.
+// It must not be modified.
+func (c *KConfig) Koanf() *koanf.Koanf {
+	return c.k.Load()
+}
+
+// String returns the string value of path, "" when it is not set.
+func (c *KConfig) String(path string) string {
+	return c.Koanf().String(path)
+}
+
+// Exists reports whether path is set.
+func (c *KConfig) Exists(path string) bool {
+	return c.Koanf().Exists(path)
+}
+
+// Copy returns a copy of the current config.
+func (c *KConfig) Copy() *koanf.Koanf {
+	return c.Koanf().Copy()
+}
+
+// Unmarshal decodes path into o, resolving secret references such as
+// file:///run/secrets/pg_password; see RegisterSecretResolver.
+func (c *KConfig) Unmarshal(path string, o any) error {
+	return c.UnmarshalWithConf(path, o, unmarshalConf(nil))
+}
+
+// UnmarshalWithConf decodes path into o with conf.
+func (c *KConfig) UnmarshalWithConf(path string, o any, conf koanf.UnmarshalConf) error {
+	return c.Koanf().UnmarshalWithConf(path, o, conf)
+}
+
+// UnknownKeys returns the keys of the current config no registered section
+// declares; they are ignored.
+func (c *KConfig) UnknownKeys() []string {
+	return UnknownConfigKeys(c.Koanf())
+}
+
+// RegisterValidator adds a check a reloaded config must pass, after the
+// registered sections passed ValidateConfig, before it replaces the current one.
+func (c *KConfig) RegisterValidator(validate func(k *koanf.Koanf) error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.validators = append(c.validators, validate)
+}
+
+// Subscribe calls fn after a reload changed key or any key below it. An empty
+// key matches every change. fn runs during Reload and must not call it again.
+func (c *KConfig) Subscribe(key string, fn func(ctx context.Context, c *KConfig)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.subscribers = append(c.subscribers, configSubscriber{key: key, fn: fn})
+}
+
+// Reload loads the config again and, when every validator accepts it,
+// replaces the current config and notifies the subscribers of changed keys.
+// A rejected config leaves the current one in place.
+func (c *KConfig) Reload(ctx context.Context) error {
+	c.reloadMu.Lock()
+	defer c.reloadMu.Unlock()
+
+	k, sources, err := c.load()
+	if err == nil {
+		err = c.validate(k)
+	}
+
+	if err != nil {
+		c.countReload(ctx, "rejected")
+
+		return err
+	}
+
+	c.mu.Lock()
+	changed := changedKeys(c.k.Load(), k)
+	c.k.Store(k)
+	c.sources = sources
+
+	var notify []configSubscriber
+
+	for _, s := range c.subscribers {
+		if slices.ContainsFunc(changed, func(key string) bool { return keyMatches(s.key, key) }) {
+			notify = append(notify, s)
+		}
+	}
+	c.mu.Unlock()
+
+	c.countReload(ctx, "applied")
+
+	for _, s := range notify {
+		s.fn(ctx, c)
+	}
+
+	return nil
+}
+
+func (c *KConfig) countReload(ctx context.Context, result string) {
+	if c.reloads != nil {
+		c.reloads.Add(ctx, 1, otelmetricapi.WithAttributes(attribute.String("result", result)))
+	}
+}
+
+func (c *KConfig) validate(k *koanf.Koanf) error {
+	if err := ValidateConfig(k); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	validators := slices.Clone(c.validators)
+	c.mu.Unlock()
+
+	var errs []error
+
+	for _, validate := range validators {
+		if err := validate(k); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.Join(append([]error{ErrInvalidConfig}, errs...)...)
+	}
+
+	return nil
+}
+
+// Watch reloads the config whenever one of its files changes until Unwatch
+// is called. Files added to a config directory later are not watched, but
+// are merged by the next reload.
+func (c *KConfig) Watch(ctx context.Context, logger *slog.Logger) error {
+	ctx = context.WithoutCancel(ctx)
+	logger = logger.With("component", "config")
+
+	c.mu.Lock()
+	sources := slices.Clone(c.sources)
+	c.mu.Unlock()
+
+	for _, source := range sources {
+		f := file.Provider(source)
+
+		if err := f.Watch(func(_ any, err error) {
+			if err != nil {
+				logger.Error("Config watch failed", "path", source, "error", err)
+
+				return
+			}
+
+			if err := c.Reload(ctx); err != nil {
+				logger.Error("Config reload rejected, keeping the current config", "path", source, "error", err)
+
+				return
+			}
+
+			logger.Info("Config reloaded", "path", source)
+			warnUnknownKeys(ctx, logger, c)
+		}); err != nil {
+			return errors.Join(err, c.Unwatch(ctx))
+		}
+
+		c.mu.Lock()
+		c.files = append(c.files, f)
+		c.mu.Unlock()
 	}
 
-	return &KConfig{k}, nil
+	return nil
+}
+
+// Unwatch stops watching the config files.
+func (c *KConfig) Unwatch(_ context.Context) error {
+	c.mu.Lock()
+	files := c.files
+	c.files = nil
+	c.mu.Unlock()
+
+	var errs []error
+
+	for _, f := range files {
+		errs = append(errs, f.Unwatch())
+	}
+
+	return errors.Join(errs...)
+}
+
+// changedKeys lists the keys added, removed or changed between old and updated.
+func changedKeys(old, updated *koanf.Koanf) []string {
+	before, after := old.All(), updated.All()
+
+	var keys []string
+
+	for key, value := range after {
+		if prev, ok := before[key]; !ok || !reflect.DeepEqual(prev, value) {
+			keys = append(keys, key)
+		}
+	}
+
+	for key := range before {
+		if _, ok := after[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+
+	return keys
+}
+
+func keyMatches(subscribed, changed string) bool {
+	return subscribed == "" || changed == subscribed || strings.HasPrefix(changed, subscribed+".")
+}
+
+// warnUnknownKeys logs the keys of c no registered section declares, which
+// are typically typos or settings of removed features.
+func warnUnknownKeys(ctx context.Context, logger *slog.Logger, c *KConfig) {
+	if unknown := c.UnknownKeys(); len(unknown) > 0 {
+		logger.WarnContext(ctx, "Config has unknown keys, they are ignored", "keys", unknown)
+	}
 }
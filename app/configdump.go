@@ -0,0 +1,192 @@
+package app
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	yamlv3 "go.yaml.in/yaml/v3"
+)
+
+// ConfigDefaulter is implemented by config structs whose defaults are not zero values.
+type ConfigDefaulter interface {
+	SetDefaults()
+}
+
+// Redacted replaces the value of secrets.
+const Redacted = "<redacted>"
+
+// secretKeyParts mark a key as secret when its last segment contains one of them.
+var secretKeyParts = []string{"password", "passwd", "secret", "token", "dsn", "credential", "private_key", "api_key", "apikey"}
+
+// IsSecretKey reports whether the value of key must not be shown.
+func IsSecretKey(key string) bool {
+	name := strings.ToLower(key[strings.LastIndex(key, ".")+1:])
+
+	return slices.ContainsFunc(secretKeyParts, func(part string) bool { return strings.Contains(name, part) })
+}
+
+// RedactedYAML marshals the effective config with the values of secrets
+// redacted. Secret references keep their prefix, e.g. "file://<redacted>".
+// Lists and maps are walked too, so replicas[].dsn is redacted like dsn.
+func (c *KConfig) RedactedYAML() ([]byte, error) {
+	return encodeYAML(redactValue("", c.Copy().Raw()))
+}
+
+// redactValue returns v with every secret below it redacted. key is the name
+// v is stored under; the elements of a list inherit the key of the list.
+func redactValue(key string, v any) any {
+	switch rv := reflect.ValueOf(v); rv.Kind() {
+	case reflect.Map:
+		out := make(map[string]any, rv.Len())
+		for iter := rv.MapRange(); iter.Next(); {
+			name := fmt.Sprint(iter.Key().Interface())
+			out[name] = redactValue(name, iter.Value().Interface())
+		}
+
+		return out
+	case reflect.Slice, reflect.Array:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			break
+		}
+
+		out := make([]any, rv.Len())
+		for i := range rv.Len() {
+			out[i] = redactValue(key, rv.Index(i).Interface())
+		}
+
+		return out
+	case reflect.Invalid:
+		return v
+	}
+
+	value := fmt.Sprint(v)
+
+	switch prefix, _ := secretPrefix(value); {
+	case prefix != "":
+		return prefix + Redacted
+	case IsSecretKey(key) && value != "":
+		return Redacted
+	default:
+		return v
+	}
+}
+
+// DefaultConfigYAML renders every registered section with its default values,
+// each key annotated with the doc tag of its field.
+func DefaultConfigYAML() ([]byte, error) {
+	configSectionsMu.Lock()
+	sections := slices.Clone(configSections)
+	configSectionsMu.Unlock()
+
+	root := &yamlv3.Node{Kind: yamlv3.MappingNode}
+
+	for _, s := range sections {
+		parts := strings.Split(s.key, ".")
+
+		parent := root
+		for _, part := range parts[:len(parts)-1] {
+			parent = mappingChild(parent, part)
+		}
+
+		appendKey(parent, parts[len(parts)-1], "", valueNode(reflect.ValueOf(s.newConfig()).Elem()))
+	}
+
+	doc := &yamlv3.Node{
+		Kind:        yamlv3.DocumentNode,
+		HeadComment: "Every known key with its default value. APP_ env vars override any key, e.g. APP_APP_TITLE; separate the segments of keys with underscores by __, e.g. APP_APP__SHUTDOWN_TIMEOUT.",
+		Content:     []*yamlv3.Node{root},
+	}
+
+	return encodeYAML(doc)
+}
+
+func encodeYAML(v any) ([]byte, error) {
+	var buf bytes.Buffer
+
+	enc := yamlv3.NewEncoder(&buf)
+	enc.SetIndent(2) //nolint:mnd
+
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// mappingChild returns the mapping stored under key in parent, adding it when missing.
+func mappingChild(parent *yamlv3.Node, key string) *yamlv3.Node {
+	for i := 0; i+1 < len(parent.Content); i += 2 {
+		if parent.Content[i].Value == key {
+			return parent.Content[i+1]
+		}
+	}
+
+	child := &yamlv3.Node{Kind: yamlv3.MappingNode}
+	appendKey(parent, key, "", child)
+
+	return child
+}
+
+func appendKey(mapping *yamlv3.Node, key, doc string, value *yamlv3.Node) {
+	mapping.Content = append(mapping.Content,
+		&yamlv3.Node{Kind: yamlv3.ScalarNode, Value: key, HeadComment: doc},
+		value,
+	)
+}
+
+var durationType = reflect.TypeFor[time.Duration]()
+
+func valueNode(v reflect.Value) *yamlv3.Node {
+	switch {
+	case v.Type() == durationType:
+		return &yamlv3.Node{Kind: yamlv3.ScalarNode, Value: v.Interface().(time.Duration).String()} //nolint:forcetypeassert
+	case v.Kind() == reflect.Struct:
+		return structNode(v)
+	case v.Kind() == reflect.Slice:
+		seq := &yamlv3.Node{Kind: yamlv3.SequenceNode, Style: yamlv3.FlowStyle}
+		for i := range v.Len() {
+			seq.Content = append(seq.Content, valueNode(v.Index(i)))
+		}
+
+		return seq
+	default:
+		n := new(yamlv3.Node)
+		if err := n.Encode(v.Interface()); err != nil {
+			return &yamlv3.Node{Kind: yamlv3.ScalarNode, Tag: "!!null"}
+		}
+
+		return n
+	}
+}
+
+func structNode(v reflect.Value) *yamlv3.Node {
+	mapping := &yamlv3.Node{Kind: yamlv3.MappingNode}
+
+	for i := range v.NumField() {
+		field := v.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		key := field.Tag.Get("koanf")
+		if key == "-" {
+			continue
+		}
+
+		if key == "" {
+			key = strings.ToLower(field.Name)
+		}
+
+		appendKey(mapping, key, field.Tag.Get("doc"), valueNode(v.Field(i)))
+	}
+
+	return mapping
+}
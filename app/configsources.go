@@ -0,0 +1,110 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+var ErrConfigProfileNotFound = errors.New("config profile not found")
+
+// configSources resolves the files to merge, in order: every path as given,
+// a directory contributing its *.yaml and *.yml files in lexical order, then
+// config.<profile>.yaml from the directory of each path. Profile files are
+// skipped when listing directories, so only the selected profile is loaded.
+func configSources(paths []string, profile string) ([]string, error) {
+	if len(paths) == 0 {
+		paths = []string{DefaultConfigPath}
+	}
+
+	var sources, dirs []string
+
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+
+		if !info.IsDir() {
+			sources = appendSource(sources, path)
+			dirs = appendSource(dirs, filepath.Dir(path))
+
+			continue
+		}
+
+		files, err := configFiles(path)
+		if err != nil {
+			return nil, err
+		}
+
+		sources = appendSource(sources, files...)
+		dirs = appendSource(dirs, path)
+	}
+
+	if profile == "" {
+		return sources, nil
+	}
+
+	found := false
+
+	for _, dir := range dirs {
+		for _, ext := range []string{".yaml", ".yml"} {
+			path := filepath.Join(dir, "config."+profile+ext)
+			if _, err := os.Stat(path); err == nil {
+				sources = appendSource(sources, path)
+				found = true
+			}
+		}
+	}
+
+	if !found {
+		return nil, fmt.Errorf("%w: no config.%s.yaml in %s", ErrConfigProfileNotFound, profile, strings.Join(dirs, ", "))
+	}
+
+	return sources, nil
+}
+
+// configFiles lists the yaml files of dir, except profile files, in lexical order.
+func configFiles(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+
+	for _, e := range entries {
+		ext := filepath.Ext(e.Name())
+		if e.IsDir() || (ext != ".yaml" && ext != ".yml") || isProfileFile(e.Name()) {
+			continue
+		}
+
+		files = append(files, filepath.Join(dir, e.Name()))
+	}
+
+	slices.Sort(files)
+
+	return files, nil
+}
+
+// isProfileFile reports whether name is config.<profile>.yaml or .yml.
+func isProfileFile(name string) bool {
+	base := strings.TrimSuffix(name, filepath.Ext(name))
+
+	return strings.HasPrefix(base, "config.") && len(base) > len("config.")
+}
+
+// appendSource appends the paths not yet in sources; a file listed twice is
+// loaded at its first position only.
+func appendSource(sources []string, paths ...string) []string {
+	for _, path := range paths {
+		if !slices.Contains(sources, filepath.Clean(path)) {
+			sources = append(sources, filepath.Clean(path))
+		}
+	}
+
+	return sources
+}
@@ -1,14 +1,25 @@
 package app
 
 import (
+	"cmp"
 	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
 type healthzOptions struct {
-	timeout   time.Duration
-	liveness  bool
-	readiness bool
+	timeout          time.Duration
+	liveness         bool
+	readiness        bool
+	startup          bool
+	interval         time.Duration
+	failureThreshold int
+	severity         Severity
 }
 
 // HealthzOption is a function option for healthz registration.
@@ -33,87 +44,503 @@ func WithReadiness(readiness bool) HealthzOption {
 	}
 }
 
+// WithStartup adds the check to the startup probe, which also fails until
+// every component started.
+func WithStartup(startup bool) HealthzOption {
+	return func(o *healthzOptions) {
+		o.startup = startup
+	}
+}
+
+// WithInterval runs the check in the background every d and serves the
+// cached result to the probes. Zero runs the check on every probe.
+func WithInterval(d time.Duration) HealthzOption {
+	return func(o *healthzOptions) {
+		o.interval = d
+	}
+}
+
+// WithSeverity sets how a failure of the check affects the probes; the default is SeverityCritical.
+func WithSeverity(severity Severity) HealthzOption {
+	return func(o *healthzOptions) {
+		o.severity = severity
+	}
+}
+
+// WithFailureThreshold sets how many consecutive failures fail the check.
+// Each probe counts the failures it saw; a check with an interval counts
+// its background runs.
+func WithFailureThreshold(n int) HealthzOption {
+	return func(o *healthzOptions) {
+		o.failureThreshold = max(n, 1)
+	}
+}
+
+// Hook is a named lifecycle function registered with the controller.
+type Hook struct {
+	Name string
+	Func func(ctx context.Context) error
+	// Timeout bounds a single run of Func; zero means the caller's default.
+	Timeout time.Duration
+	// After lists the hooks of the same kind that must finish before Func runs.
+	After []string
+}
+
+type hookOptions struct {
+	dependsOn []string
+	priority  int
+	timeout   time.Duration
+}
+
+// HookOption is a function option for startup and shutdown registration.
+type HookOption func(*hookOptions)
+
+// WithDependsOn declares components that must be started before, and shut down after, this one.
+func WithDependsOn(names ...string) HookOption {
+	return func(o *hookOptions) {
+		o.dependsOn = append(o.dependsOn, names...)
+	}
+}
+
+// WithPriority orders components that have no dependency between them.
+// Lower values start first and shut down last; ties keep registration order.
+func WithPriority(priority int) HookOption {
+	return func(o *hookOptions) {
+		o.priority = priority
+	}
+}
+
+// WithHookTimeout sets a deadline for the hook being registered.
+func WithHookTimeout(d time.Duration) HookOption {
+	return func(o *hookOptions) {
+		o.timeout = d
+	}
+}
+
+var (
+	ErrHookCycle             = errors.New("lifecycle hooks have a dependency cycle")
+	ErrHookMissingDependency = errors.New("lifecycle hook depends on an unregistered component")
+)
+
 type Controller interface {
-	GetSutdowners() map[string]func(ctx context.Context) error
-	GetStarters() map[string]func(ctx context.Context) error
-	RegisterShutdown(name string, shutdown func(ctx context.Context) error)
-	RegisterStartup(name string, startup func(ctx context.Context) error)
+	GetShutdowners() ([]Hook, error)
+	GetStarters() ([]Hook, error)
+	RegisterShutdown(name string, shutdown func(ctx context.Context) error, opts ...HookOption)
+	RegisterStartup(name string, startup func(ctx context.Context) error, opts ...HookOption)
+	// Validate reports missing dependencies and cycles; the app calls it
+	// before starting any component.
+	Validate() error
 	RegisterHealthz(name string, healthz func(ctx context.Context) error, opts ...HealthzOption)
-	GetHealthzLiveness() map[string]func(ctx context.Context) error
-	GetHealthzReadiness() map[string]func(ctx context.Context) error
+	GetHealthzLiveness() []HealthzCheck
+	GetHealthzReadiness() []HealthzCheck
+	GetHealthzStartup() []HealthzCheck
+	MarkStarted()
+	IsStarted() bool
+	Drain()
+	IsDraining() bool
 }
 
 var _ Controller = (*controller)(nil)
 
+// component groups the lifecycle hooks registered under one name.
+type component struct {
+	name      string
+	seq       int
+	priority  int
+	dependsOn []string
+	startup   *Hook
+	shutdown  *Hook
+}
+
 type controller struct {
-	shutdowners      map[string]func(ctx context.Context) error
-	starters         map[string]func(ctx context.Context) error
-	healthzLiveness  map[string]func(ctx context.Context) error
-	healthzReadiness map[string]func(ctx context.Context) error
+	mu       sync.Mutex
+	started  atomic.Bool
+	draining atomic.Bool
+
+	components map[string]*component
+	healthz    []*healthzCheck
+	watchCtx   context.Context //nolint:containedctx
+	stopWatch  context.CancelFunc
+	watchers   sync.WaitGroup
+	metrics    *healthzMetrics
 }
 
 func NewController() *controller {
 	return &controller{
-		shutdowners:      make(map[string]func(ctx context.Context) error),
-		starters:         make(map[string]func(ctx context.Context) error),
-		healthzLiveness:  make(map[string]func(ctx context.Context) error),
-		healthzReadiness: make(map[string]func(ctx context.Context) error),
+		components: make(map[string]*component),
+		metrics:    newHealthzMetrics(),
 	}
 }
 
-// RegisterHealthz registers a healthz check with a name.
+// RegisterHealthz registers a healthz check with a name. A name that is
+// already registered replaces the previous check. A check with an interval
+// registered once the checks run in the background is run right away.
 func (c *controller) RegisterHealthz(name string, healthz func(ctx context.Context) error, opts ...HealthzOption) {
 	defaultTimeout := 5 * time.Second //nolint:mnd
 	options := &healthzOptions{
-		timeout:   defaultTimeout,
-		liveness:  true,
-		readiness: true,
+		timeout:          defaultTimeout,
+		liveness:         true,
+		readiness:        true,
+		failureThreshold: 1,
+		severity:         SeverityCritical,
 	}
 
 	for _, o := range opts {
 		o(options)
 	}
 
-	if options.liveness {
-		c.healthzLiveness[name] = func(ctx context.Context) error {
-			ctx, cancel := context.WithTimeout(ctx, options.timeout)
-			defer cancel()
+	check := &healthzCheck{name: name, check: healthz, options: options, metrics: c.metrics}
+
+	c.mu.Lock()
+
+	watched := slices.ContainsFunc(c.healthz, func(h *healthzCheck) bool { return h.options.interval > 0 })
 
-			return healthz(ctx)
+	c.healthz = slices.DeleteFunc(c.healthz, func(h *healthzCheck) bool {
+		if h.name != name {
+			return false
 		}
+
+		if h.stop != nil {
+			h.stop()
+		}
+
+		return true
+	})
+	c.healthz = append(c.healthz, check)
+	c.watchLocked(check)
+
+	c.mu.Unlock()
+
+	if options.interval <= 0 || watched {
+		return
 	}
 
-	if options.readiness {
-		c.healthzReadiness[name] = func(ctx context.Context) error {
-			ctx, cancel := context.WithTimeout(ctx, options.timeout)
-			defer cancel()
+	c.RegisterStartup("healthz", c.startHealthz, WithPriority(healthzPriority))
+	c.RegisterShutdown("healthz", c.stopHealthz, WithPriority(healthzPriority))
+}
+
+func (c *controller) GetHealthzLiveness() []HealthzCheck {
+	return c.healthzChecks(probeLiveness, func(o *healthzOptions) bool { return o.liveness })
+}
+
+func (c *controller) GetHealthzReadiness() []HealthzCheck {
+	return c.healthzChecks(probeReadiness, func(o *healthzOptions) bool { return o.readiness })
+}
+
+func (c *controller) GetHealthzStartup() []HealthzCheck {
+	return c.healthzChecks(probeStartup, func(o *healthzOptions) bool { return o.startup })
+}
+
+func (c *controller) healthzChecks(probe healthzProbe, include func(o *healthzOptions) bool) []HealthzCheck {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 
-			return healthz(ctx)
+	checks := make([]HealthzCheck, 0, len(c.healthz))
+
+	for _, h := range c.healthz {
+		if include(h.options) {
+			checks = append(checks, probeCheck{healthzCheck: h, probe: probe})
 		}
 	}
+
+	return checks
+}
+
+// MarkStarted marks every component as started; the startup probe may pass
+// from now on. Checks with an interval registered after the startup hooks
+// ran are run in the background from now on.
+func (c *controller) MarkStarted() {
+	c.started.Store(true)
+
+	_ = c.startHealthz(context.Background())
+}
+
+// IsStarted reports whether MarkStarted has been called.
+func (c *controller) IsStarted() bool {
+	return c.started.Load()
 }
 
-func (c *controller) GetHealthzLiveness() map[string]func(ctx context.Context) error {
-	return c.healthzLiveness
+// Drain marks the application as draining; readiness fails from now on.
+func (c *controller) Drain() {
+	c.draining.Store(true)
 }
 
-func (c *controller) GetHealthzReadiness() map[string]func(ctx context.Context) error {
-	return c.healthzReadiness
+// IsDraining reports whether Drain has been called.
+func (c *controller) IsDraining() bool {
+	return c.draining.Load()
 }
 
 // RegisterShutdown registers a shutdown function with a name.
-func (c *controller) RegisterShutdown(name string, shutdown func(ctx context.Context) error) {
-	c.shutdowners[name] = shutdown
+func (c *controller) RegisterShutdown(name string, shutdown func(ctx context.Context) error, opts ...HookOption) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	comp, options := c.component(name, opts...)
+	comp.shutdown = &Hook{Name: name, Func: shutdown, Timeout: options.timeout}
+}
+
+// RegisterStartup registers a startup function with a name.
+func (c *controller) RegisterStartup(name string, startup func(ctx context.Context) error, opts ...HookOption) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	comp, options := c.component(name, opts...)
+	comp.startup = &Hook{Name: name, Func: startup, Timeout: options.timeout}
+}
+
+// GetStarters returns the startup hooks in dependency order. Each hook lists
+// the startup hooks of the components it depends on in After.
+func (c *controller) GetStarters() ([]Hook, error) {
+	order, dependents, err := c.order()
+	if err != nil {
+		return nil, err
+	}
+
+	dependencies := make(map[string][]string, len(order))
+
+	for name, comps := range dependents {
+		for _, comp := range comps {
+			dependencies[comp.name] = append(dependencies[comp.name], name)
+		}
+	}
+
+	hooks := make([]Hook, 0, len(order))
+
+	for _, comp := range order {
+		if comp.startup == nil {
+			continue
+		}
+
+		hook := *comp.startup
+		hook.After = c.closure(comp.name, dependencies, func(other *component) bool {
+			return other.startup != nil
+		})
+		hooks = append(hooks, hook)
+	}
+
+	return hooks, nil
+}
+
+// GetShutdowners returns the shutdown hooks in reverse dependency order. Each
+// hook lists in After the shutdown hooks that must finish before it may run:
+// those of every component depending on it and of every higher priority
+// component, unless that component waits for it, as a dependency overrides
+// priority. Hooks that do not wait on each other may run concurrently.
+func (c *controller) GetShutdowners() ([]Hook, error) {
+	order, dependents, err := c.order()
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[string][]string, len(dependents))
+
+	for name, comps := range dependents {
+		for _, comp := range comps {
+			names[name] = append(names[name], comp.name)
+		}
+	}
+
+	after := make(map[string][]string, len(order))
+
+	for _, comp := range order {
+		if comp.shutdown != nil {
+			after[comp.name] = c.closure(comp.name, names, func(other *component) bool {
+				return other.shutdown != nil
+			})
+		}
+	}
+
+	hooks := make([]Hook, 0, len(order))
+
+	for i := len(order) - 1; i >= 0; i-- {
+		comp := order[i]
+		if comp.shutdown == nil {
+			continue
+		}
+
+		for _, other := range order {
+			if other.shutdown == nil || other.priority <= comp.priority ||
+				slices.Contains(after[comp.name], other.name) || waits(after, other.name, comp.name) {
+				continue
+			}
+
+			after[comp.name] = append(after[comp.name], other.name)
+		}
+
+		hook := *comp.shutdown
+		hook.After = after[comp.name]
+		hooks = append(hooks, hook)
+	}
+
+	return hooks, nil
+}
+
+// waits reports whether the hook from waits for the hook to, directly or
+// through other hooks.
+func waits(after map[string][]string, from, to string) bool {
+	visited := map[string]bool{}
+	stack := []string{from}
+
+	for len(stack) > 0 {
+		next := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if next == to {
+			return true
+		}
+
+		if visited[next] {
+			continue
+		}
+
+		visited[next] = true
+		stack = append(stack, after[next]...)
+	}
+
+	return false
 }
 
-func (c *controller) RegisterStartup(name string, startup func(ctx context.Context) error) {
-	c.starters[name] = startup
+// closure returns every component reachable from name through edges whose
+// hooks satisfy keep, in a deterministic order.
+func (c *controller) closure(name string, edges map[string][]string, keep func(*component) bool) []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	visited := map[string]bool{name: true}
+	stack := slices.Clone(edges[name])
+
+	var reached []string
+
+	for len(stack) > 0 {
+		next := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if visited[next] {
+			continue
+		}
+
+		visited[next] = true
+		stack = append(stack, edges[next]...)
+
+		if keep(c.components[next]) {
+			reached = append(reached, next)
+		}
+	}
+
+	slices.Sort(reached)
+
+	return reached
 }
 
-func (c *controller) GetSutdowners() map[string]func(ctx context.Context) error {
-	return c.shutdowners
+// Validate reports missing dependencies and dependency cycles between registered hooks.
+func (c *controller) Validate() error {
+	_, _, err := c.order()
+
+	return err
 }
 
-func (c *controller) GetStarters() map[string]func(ctx context.Context) error {
-	return c.starters
+// component returns the component registered under name, creating it on first
+// use, together with the options of the current registration.
+// The caller must hold c.mu.
+func (c *controller) component(name string, opts ...HookOption) (*component, *hookOptions) {
+	comp, ok := c.components[name]
+	if !ok {
+		comp = &component{name: name, seq: len(c.components)}
+		c.components[name] = comp
+	}
+
+	options := &hookOptions{priority: comp.priority}
+	for _, o := range opts {
+		o(options)
+	}
+
+	comp.priority = options.priority
+	comp.dependsOn = append(comp.dependsOn, options.dependsOn...)
+
+	return comp, options
+}
+
+// order sorts the registered components topologically. Components that become
+// ready at the same time are ordered by priority, then by registration order.
+// It also returns, for every component, the components that depend on it.
+func (c *controller) order() ([]*component, map[string][]*component, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	indegree := make(map[string]int, len(c.components))
+	dependents := make(map[string][]*component, len(c.components))
+
+	var errs error
+
+	for _, comp := range c.components {
+		seen := make(map[string]bool, len(comp.dependsOn))
+
+		for _, dep := range comp.dependsOn {
+			if seen[dep] {
+				continue
+			}
+
+			seen[dep] = true
+
+			if _, ok := c.components[dep]; !ok {
+				errs = errors.Join(errs, fmt.Errorf("%w: %q depends on %q", ErrHookMissingDependency, comp.name, dep))
+
+				continue
+			}
+
+			indegree[comp.name]++
+			dependents[dep] = append(dependents[dep], comp)
+		}
+	}
+
+	if errs != nil {
+		return nil, nil, errs
+	}
+
+	ready := make([]*component, 0, len(c.components))
+
+	for _, comp := range c.components {
+		if indegree[comp.name] == 0 {
+			ready = append(ready, comp)
+		}
+	}
+
+	order := make([]*component, 0, len(c.components))
+
+	for len(ready) > 0 {
+		slices.SortFunc(ready, func(a, b *component) int {
+			if a.priority != b.priority {
+				return cmp.Compare(a.priority, b.priority)
+			}
+
+			return cmp.Compare(a.seq, b.seq)
+		})
+
+		next := ready[0]
+		ready = ready[1:]
+		order = append(order, next)
+
+		for _, dependent := range dependents[next.name] {
+			indegree[dependent.name]--
+			if indegree[dependent.name] == 0 {
+				ready = append(ready, dependent)
+			}
+		}
+	}
+
+	if len(order) != len(c.components) {
+		cyclic := make([]string, 0, len(c.components)-len(order))
+
+		for name, n := range indegree {
+			if n > 0 {
+				cyclic = append(cyclic, name)
+			}
+		}
+
+		slices.Sort(cyclic)
+
+		return nil, nil, fmt.Errorf("%w: %s", ErrHookCycle, strings.Join(cyclic, ", "))
+	}
+
+	return order, dependents, nil
 }
@@ -0,0 +1,245 @@
+package app_test
+
+import (
+	"application/app"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func noop(context.Context) error { return nil }
+
+func hookNames(hooks []app.Hook) []string {
+	names := make([]string, 0, len(hooks))
+	for _, h := range hooks {
+		names = append(names, h.Name)
+	}
+
+	return names
+}
+
+func TestControllerOrder(t *testing.T) {
+	c := app.NewController()
+
+	c.RegisterShutdown("pgx", noop, app.WithDependsOn("otlp"))
+	c.RegisterStartup("cache", noop, app.WithDependsOn("pgx"))
+	c.RegisterShutdown("cache", noop)
+	c.RegisterStartup("otlp", noop)
+	c.RegisterShutdown("otlp", noop)
+	c.RegisterStartup("metrics", noop, app.WithPriority(-1))
+
+	starters, err := c.GetStarters()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"metrics", "otlp", "cache"}, hookNames(starters))
+
+	shutdowners, err := c.GetShutdowners()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"cache", "pgx", "otlp"}, hookNames(shutdowners))
+}
+
+func TestControllerRegistrationOrder(t *testing.T) {
+	c := app.NewController()
+
+	for _, name := range []string{"c", "a", "b"} {
+		c.RegisterStartup(name, noop)
+		c.RegisterShutdown(name, noop)
+	}
+
+	starters, err := c.GetStarters()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"c", "a", "b"}, hookNames(starters))
+
+	shutdowners, err := c.GetShutdowners()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b", "a", "c"}, hookNames(shutdowners))
+}
+
+func TestControllerMissingDependency(t *testing.T) {
+	c := app.NewController()
+	c.RegisterStartup("pgx", noop, app.WithDependsOn("otlp"))
+
+	require.ErrorIs(t, c.Validate(), app.ErrHookMissingDependency)
+
+	_, err := c.GetStarters()
+	require.ErrorIs(t, err, app.ErrHookMissingDependency)
+}
+
+func TestControllerCycle(t *testing.T) {
+	c := app.NewController()
+	c.RegisterStartup("a", noop, app.WithDependsOn("b"))
+	c.RegisterStartup("b", noop, app.WithDependsOn("a"))
+	c.RegisterStartup("c", noop)
+
+	err := c.Validate()
+	require.ErrorIs(t, err, app.ErrHookCycle)
+	assert.Contains(t, err.Error(), "a, b")
+
+	_, err = c.GetShutdowners()
+	require.ErrorIs(t, err, app.ErrHookCycle)
+}
+
+func TestControllerShutdownAfter(t *testing.T) {
+	c := app.NewController()
+
+	c.RegisterShutdown("otlp", noop)
+	c.RegisterStartup("pool", noop, app.WithDependsOn("otlp"))
+	c.RegisterShutdown("repo", noop, app.WithDependsOn("pool"))
+	c.RegisterShutdown("http", noop, app.WithPriority(10))
+
+	shutdowners, err := c.GetShutdowners()
+	require.NoError(t, err)
+
+	after := make(map[string][]string, len(shutdowners))
+	for _, h := range shutdowners {
+		after[h.Name] = h.After
+	}
+
+	assert.Equal(t, []string{"repo", "http"}, after["otlp"])
+	assert.Equal(t, []string{"http"}, after["repo"])
+	assert.Empty(t, after["http"])
+}
+
+func TestControllerShutdownDependencyOverridesPriority(t *testing.T) {
+	c := app.NewController()
+
+	var (
+		mu       sync.Mutex
+		finished []string
+	)
+
+	record := func(name string) func(context.Context) error {
+		return func(context.Context) error {
+			mu.Lock()
+			finished = append(finished, name)
+			mu.Unlock()
+
+			return nil
+		}
+	}
+
+	c.RegisterShutdown("http", record("http"), app.WithPriority(10))
+	c.RegisterShutdown("pool", record("pool"))
+	c.RegisterShutdown("watcher", record("watcher"), app.WithDependsOn("http"))
+
+	shutdowners, err := c.GetShutdowners()
+	require.NoError(t, err)
+
+	after := make(map[string][]string, len(shutdowners))
+	for _, h := range shutdowners {
+		after[h.Name] = h.After
+	}
+
+	assert.Equal(t, []string{"watcher"}, after["http"])
+	assert.Empty(t, after["watcher"], "depending on http overrides its higher priority")
+	assert.Equal(t, []string{"http"}, after["pool"])
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	require.NoError(t, app.RunShutdowners(ctx, slog.New(slog.NewTextHandler(io.Discard, nil)), shutdowners, 0))
+	assert.Equal(t, []string{"watcher", "http", "pool"}, finished)
+}
+
+func TestHealthzFailureThreshold(t *testing.T) {
+	c := app.NewController()
+
+	errDown := errors.New("down")
+	c.RegisterHealthz("db", func(context.Context) error { return errDown }, app.WithFailureThreshold(2))
+
+	checks := c.GetHealthzReadiness()
+	require.Len(t, checks, 1)
+
+	result := checks[0].Check(context.Background())
+	require.ErrorIs(t, result.Err, errDown)
+	assert.Equal(t, 1, result.Failures)
+	assert.False(t, result.Failing)
+
+	result = checks[0].Check(context.Background())
+	assert.Equal(t, 2, result.Failures)
+	assert.True(t, result.Failing)
+
+	result = c.GetHealthzLiveness()[0].Check(context.Background())
+	assert.Equal(t, 1, result.Failures, "each probe counts its own failures")
+	assert.False(t, result.Failing)
+}
+
+func TestHealthzInterval(t *testing.T) {
+	c := app.NewController()
+
+	var calls atomic.Int32
+
+	c.RegisterHealthz("db", func(context.Context) error {
+		calls.Add(1)
+
+		return nil
+	}, app.WithInterval(time.Hour), app.WithStartup(true))
+
+	assert.Len(t, c.GetHealthzStartup(), 1)
+
+	starters, err := c.GetStarters()
+	require.NoError(t, err)
+	require.Equal(t, []string{"healthz"}, hookNames(starters))
+	require.NoError(t, starters[0].Func(context.Background()))
+
+	require.Eventually(t, func() bool { return calls.Load() == 1 }, time.Second, time.Millisecond)
+
+	check := c.GetHealthzLiveness()[0]
+	for range 3 {
+		require.NoError(t, check.Check(context.Background()).Err)
+	}
+
+	assert.Equal(t, int32(1), calls.Load())
+
+	shutdowners, err := c.GetShutdowners()
+	require.NoError(t, err)
+	require.NoError(t, shutdowners[0].Func(context.Background()))
+}
+
+func TestHealthzIntervalAfterStart(t *testing.T) {
+	c := app.NewController()
+	c.MarkStarted()
+
+	var first, second atomic.Int32
+
+	c.RegisterHealthz("db", func(context.Context) error {
+		first.Add(1)
+
+		return nil
+	}, app.WithInterval(10*time.Millisecond))
+
+	require.Eventually(t, func() bool { return first.Load() > 1 }, time.Second, time.Millisecond,
+		"a check registered after start runs in the background")
+
+	c.RegisterHealthz("db", func(context.Context) error {
+		second.Add(1)
+
+		return errors.New("down")
+	}, app.WithInterval(10*time.Millisecond))
+
+	require.Eventually(t, func() bool {
+		return c.GetHealthzReadiness()[0].Check(context.Background()).Failures > 1
+	}, time.Second, time.Millisecond, "the replacing check runs in the background")
+
+	stopped := first.Load()
+
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, stopped, first.Load(), "the replaced check no longer runs")
+
+	shutdowners, err := c.GetShutdowners()
+	require.NoError(t, err)
+	require.Equal(t, []string{"healthz"}, hookNames(shutdowners))
+	require.NoError(t, shutdowners[0].Func(context.Background()))
+
+	stopped = second.Load()
+
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, stopped, second.Load())
+}
@@ -0,0 +1,30 @@
+package app
+
+import (
+	"sync"
+	"time"
+)
+
+// SetListenFDsStart moves the first inherited descriptor so tests can pass
+// listeners without owning fd 3. The returned func restores it and forgets
+// which descriptors were taken.
+func SetListenFDsStart(fd int) func() {
+	prev := listenFDsStart
+	listenFDsStart = fd
+
+	return func() {
+		listenFDsStart = prev
+		takenFDs = sync.Map{}
+	}
+}
+
+// CloseListener closes the listening socket under a started server, so its
+// Serve fails as when the socket breaks.
+func CloseListener(s HTTPServer) error {
+	return s.(*httpServer).rawLn.Close() //nolint:forcetypeassert
+}
+
+// ShutdownTimeout returns the default deadline of the shutdown hooks.
+func ShutdownTimeout(c *appConfig) time.Duration {
+	return c.ShutdownTimeout
+}
@@ -1,18 +1,45 @@
 package app
 
-import "flag"
+import (
+	"flag"
+	"os"
+	"strings"
+)
 
-type runTimeFlags struct {
-	configYamlAddress string
-}
+// EnvProfile selects the config profile when -profile is not given.
+const EnvProfile = "APP_PROFILE"
+
+// DefaultConfigPath is loaded when no -config flag is given.
+const DefaultConfigPath = "./config.yaml"
 
-func NewRunTimeFlags() *runTimeFlags {
-	flags := new(runTimeFlags)
+// RunTimeFlags are the command line flags shared by the commands that load the config.
+type RunTimeFlags struct {
+	// ConfigPaths are files and directories, merged in order; see NewKoanfConfig.
+	ConfigPaths []string
+	Profile     string
+}
 
-	configAddress := flag.String("config", "./config.yaml", "config file address")
-	flag.Parse()
+// NewRunTimeFlags registers the flags on fs; they are set once fs is parsed.
+func NewRunTimeFlags(fs *flag.FlagSet) *RunTimeFlags {
+	flags := &RunTimeFlags{Profile: os.Getenv(EnvProfile)}
 
-	flags.configYamlAddress = *configAddress
+	fs.Var((*pathsFlag)(&flags.ConfigPaths), "config",
+		"config file or directory, repeatable; later ones override earlier ones (default "+DefaultConfigPath+")")
+	fs.StringVar(&flags.Profile, "profile", flags.Profile,
+		"load config.<profile>.yaml on top of the config, defaults to $"+EnvProfile)
 
 	return flags
 }
+
+// pathsFlag collects every occurrence of a repeated flag.
+type pathsFlag []string
+
+func (p *pathsFlag) String() string {
+	return strings.Join(*p, ",")
+}
+
+func (p *pathsFlag) Set(value string) error {
+	*p = append(*p, value)
+
+	return nil
+}
@@ -0,0 +1,270 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelmetricapi "go.opentelemetry.io/otel/metric"
+)
+
+// healthzPriority starts the background checks after, and stops them before,
+// every component without a higher priority, but before the HTTP servers.
+const healthzPriority = httpServerPriority - 1
+
+// Severity is how a failing healthz check affects the probes.
+type Severity string
+
+const (
+	// SeverityCritical failures fail the probe.
+	SeverityCritical Severity = "critical"
+	// SeverityDegraded failures keep the probe passing but report it degraded.
+	SeverityDegraded Severity = "degraded"
+	// SeverityInformational failures are only reported.
+	SeverityInformational Severity = "informational"
+)
+
+// HealthzResult is the state of a healthz check after its latest run.
+type HealthzResult struct {
+	// Err is the error of the latest run, nil when it passed.
+	Err error
+	// Failures counts the consecutive failed runs.
+	Failures int
+	// Failing is set once Failures reached the failure threshold.
+	Failing bool
+	Latency time.Duration
+	Time    time.Time
+}
+
+// HealthzCheck is a check registered with RegisterHealthz, as seen by one probe.
+type HealthzCheck interface {
+	Name() string
+	Severity() Severity
+	// Check runs the check, or returns the latest cached result when the
+	// check runs on an interval.
+	Check(ctx context.Context) HealthzResult
+}
+
+// healthzProbe names who runs a check. Each counts its own consecutive
+// failures, so a check in several probes is not counted once per probe.
+type healthzProbe string
+
+const (
+	probeLiveness  healthzProbe = "liveness"
+	probeReadiness healthzProbe = "readiness"
+	probeStartup   healthzProbe = "startup"
+	// probeScheduled is the background run of a check with an interval.
+	probeScheduled healthzProbe = "scheduled"
+)
+
+type healthzCheck struct {
+	name    string
+	check   func(ctx context.Context) error
+	options *healthzOptions
+	metrics *healthzMetrics
+
+	mu      sync.Mutex
+	results map[healthzProbe]*HealthzResult
+	// stop ends the background runs, nil when the check is not watched.
+	stop context.CancelFunc
+}
+
+func (h *healthzCheck) Name() string {
+	return h.name
+}
+
+func (h *healthzCheck) Severity() Severity {
+	return h.options.severity
+}
+
+// checkFor serves the check to probe.
+func (h *healthzCheck) checkFor(ctx context.Context, probe healthzProbe) HealthzResult {
+	if h.options.interval > 0 {
+		h.mu.Lock()
+		result := h.results[probeScheduled]
+		h.mu.Unlock()
+
+		// Until the first background run the check runs live.
+		if result != nil {
+			return *result
+		}
+	}
+
+	return h.run(ctx, probe)
+}
+
+// run executes the check under its timeout and records the result of probe.
+func (h *healthzCheck) run(ctx context.Context, probe healthzProbe) HealthzResult {
+	ctx, cancel := context.WithTimeout(ctx, h.options.timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := h.check(ctx)
+	latency := time.Since(start)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	result := HealthzResult{Err: err, Latency: latency, Time: start}
+
+	if err != nil {
+		result.Failures = 1
+		if previous := h.results[probe]; previous != nil {
+			result.Failures += previous.Failures
+		}
+	}
+
+	result.Failing = result.Failures >= h.options.failureThreshold
+
+	if h.results == nil {
+		h.results = make(map[healthzProbe]*HealthzResult)
+	}
+
+	h.results[probe] = &result
+
+	h.metrics.record(ctx, h, probe, result)
+
+	return result
+}
+
+var _ HealthzCheck = probeCheck{}
+
+// probeCheck is a check as served to one probe.
+type probeCheck struct {
+	*healthzCheck
+	probe healthzProbe
+}
+
+func (p probeCheck) Check(ctx context.Context) HealthzResult {
+	return p.checkFor(ctx, p.probe)
+}
+
+// healthzMetrics exposes the state of every healthz check after each run.
+type healthzMetrics struct {
+	up       otelmetricapi.Int64Gauge
+	failures otelmetricapi.Int64Gauge
+	latency  otelmetricapi.Float64Histogram
+}
+
+func newHealthzMetrics() *healthzMetrics {
+	meter := otel.Meter("application/app/healthz")
+
+	up, upErr := meter.Int64Gauge("healthz.check.up",
+		otelmetricapi.WithDescription("1 when the healthz check is not failing, 0 otherwise"))
+
+	failures, failuresErr := meter.Int64Gauge("healthz.check.failures",
+		otelmetricapi.WithDescription("Consecutive failures of the healthz check"))
+
+	latency, latencyErr := meter.Float64Histogram("healthz.check.duration",
+		otelmetricapi.WithDescription("Duration of healthz check runs"), otelmetricapi.WithUnit("s"))
+
+	if err := errors.Join(upErr, failuresErr, latencyErr); err != nil {
+		otel.Handle(err)
+	}
+
+	return &healthzMetrics{up: up, failures: failures, latency: latency}
+}
+
+func (m *healthzMetrics) record(ctx context.Context, h *healthzCheck, probe healthzProbe, result HealthzResult) {
+	// The check context may be canceled; recording must not be dropped with it.
+	ctx = context.WithoutCancel(ctx)
+
+	attrs := otelmetricapi.WithAttributes(
+		attribute.String("check", h.name),
+		attribute.String("severity", string(h.options.severity)),
+		attribute.String("probe", string(probe)),
+	)
+
+	var up int64
+	if !result.Failing {
+		up = 1
+	}
+
+	m.up.Record(ctx, up, attrs)
+	m.failures.Record(ctx, int64(result.Failures), attrs)
+	m.latency.Record(ctx, result.Latency.Seconds(), attrs)
+}
+
+// watch runs the check every interval until ctx is done.
+func (h *healthzCheck) watch(ctx context.Context) {
+	ticker := time.NewTicker(h.options.interval)
+	defer ticker.Stop()
+
+	h.run(ctx, probeScheduled)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			h.run(ctx, probeScheduled)
+		}
+	}
+}
+
+// startHealthz runs the checks registered with an interval in the background.
+// Checks registered later are watched from their registration on.
+func (c *controller) startHealthz(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.watchCtx != nil {
+		return nil
+	}
+
+	c.watchCtx, c.stopWatch = context.WithCancel(context.WithoutCancel(ctx))
+
+	for _, h := range c.healthz {
+		c.watchLocked(h)
+	}
+
+	return nil
+}
+
+// watchLocked runs h in the background once watching started. c.mu must be held.
+func (c *controller) watchLocked(h *healthzCheck) {
+	if h.options.interval <= 0 || c.watchCtx == nil || c.watchCtx.Err() != nil {
+		return
+	}
+
+	ctx, stop := context.WithCancel(c.watchCtx)
+	h.stop = stop
+
+	c.watchers.Add(1)
+
+	go func() {
+		defer c.watchers.Done()
+
+		h.watch(ctx)
+	}()
+}
+
+// stopHealthz stops the background checks and waits for running checks to return.
+func (c *controller) stopHealthz(ctx context.Context) error {
+	c.mu.Lock()
+	stop := c.stopWatch
+	c.mu.Unlock()
+
+	if stop == nil {
+		return nil
+	}
+
+	stop()
+
+	done := make(chan struct{})
+
+	go func() {
+		c.watchers.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -2,11 +2,16 @@ package app
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"log/slog"
+	"net"
 	"net/http"
+	"os"
 	"runtime/debug"
+	"strings"
+	"time"
 
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 	"go.opentelemetry.io/otel"
@@ -16,9 +21,55 @@ import (
 )
 
 type httpServerConfig struct {
-	HTTP struct {
-		Addr string `koanf:"addr"`
-	} `koanf:"http"`
+	HTTP httpListenerConfig `koanf:"http" doc:"public API server"`
+	// Admin hosts metrics, pprof, healthz and docs. Without an address these
+	// routes, except pprof, are served by the HTTP server instead.
+	Admin httpListenerConfig `koanf:"admin" doc:"metrics, pprof, healthz and docs; leave addr empty to serve them, without pprof, from http"`
+}
+
+// httpListenerConfig configures one http.Server. Zero values keep the Go defaults.
+type httpListenerConfig struct {
+	// Addr is host:port, unix:///path/to.sock or systemd://name, see Listen.
+	Addr string `koanf:"addr" doc:"host:port, unix:///path/to.sock or systemd://name"`
+	// SocketMode is the octal permission of a unix socket, e.g. "0660".
+	SocketMode string `koanf:"socket_mode" doc:"octal permissions of a unix socket, e.g. 0660"`
+	// DrainDelay is how long readiness reports draining before the server stops accepting connections.
+	DrainDelay        time.Duration `koanf:"drain_delay"         doc:"how long readiness reports draining before the listener closes"`
+	ReadTimeout       time.Duration `koanf:"read_timeout"`
+	ReadHeaderTimeout time.Duration `koanf:"read_header_timeout"`
+	WriteTimeout      time.Duration `koanf:"write_timeout"`
+	IdleTimeout       time.Duration `koanf:"idle_timeout"`
+	MaxHeaderBytes    int           `koanf:"max_header_bytes"`
+	TLS               tlsConfig     `koanf:"tls"`
+	// Protocols lists http1, http2 (over TLS) and h2c; empty means http1 and http2.
+	Protocols []string    `koanf:"protocols" doc:"http1, http2 (over TLS) and h2c; empty means http1 and http2"`
+	HTTP2     http2Config `koanf:"http2"     doc:"zero values keep the Go defaults"`
+}
+
+func (c *httpServerConfig) Validate() error {
+	return errors.Join(
+		Required("http.addr", c.HTTP.Addr),
+		PrefixErrors("http", c.HTTP.Validate()),
+		PrefixErrors("admin", c.Admin.Validate()),
+	)
+}
+
+func (c *httpListenerConfig) Validate() error {
+	_, socketModeErr := ParseSocketMode(c.SocketMode)
+	_, protocolsErr := parseProtocols(c.Protocols)
+
+	return errors.Join(
+		Invalid("socket_mode", socketModeErr),
+		NonNegative("drain_delay", c.DrainDelay),
+		NonNegative("read_timeout", c.ReadTimeout),
+		NonNegative("read_header_timeout", c.ReadHeaderTimeout),
+		NonNegative("write_timeout", c.WriteTimeout),
+		NonNegative("idle_timeout", c.IdleTimeout),
+		NonNegative("max_header_bytes", c.MaxHeaderBytes),
+		Invalid("protocols", protocolsErr),
+		PrefixErrors("tls", c.TLS.Validate()),
+		PrefixErrors("http2", c.HTTP2.Validate()),
+	)
 }
 
 func NewHTTPServerConfig(ctx context.Context, c *KConfig) (*httpServerConfig, error) {
@@ -30,60 +81,252 @@ func NewHTTPServerConfig(ctx context.Context, c *KConfig) (*httpServerConfig, er
 	return config, nil
 }
 
+// PublicHTTPHandler serves the business API routes.
+type PublicHTTPHandler interface {
+	http.Handler
+}
+
+// AdminHTTPHandler serves the operational routes. Like http.ServeMux it
+// reports the pattern matching a request, so its routes can share the public
+// listener when no admin address is configured.
+type AdminHTTPHandler interface {
+	http.Handler
+	Handler(r *http.Request) (h http.Handler, pattern string)
+}
+
 type HTTPServer interface {
+	Name() string
+	// Addr is the bound listener address, nil before Start.
+	Addr() net.Addr
 	Start(ctx context.Context) error
 	Shutdown(ctx context.Context) error
+	// Errors delivers errors that stop the server after Start returned.
+	Errors() <-chan error
+	// ListenerFile duplicates the listening socket to hand it to a new process.
+	ListenerFile() (*os.File, error)
+}
+
+// HTTPServers are the named servers of the application.
+type HTTPServers []HTTPServer
+
+// httpServerPriority makes HTTP servers start after, and shut down before,
+// every component without a higher priority.
+const httpServerPriority = 100
+
+const (
+	PublicHTTPServerName = "http"
+	AdminHTTPServerName  = "admin"
+)
+
+// NewHTTPServers creates the public server and, when it has an address, the
+// admin server. Each server registers itself with the controller.
+func NewHTTPServers(
+	cfg *httpServerConfig,
+	public PublicHTTPHandler,
+	admin AdminHTTPHandler,
+	appLogger AppLogger,
+	controller Controller,
+) HTTPServers {
+	if cfg.Admin.Addr == "" {
+		return HTTPServers{
+			NewHTTPServer(PublicHTTPServerName, &cfg.HTTP, withAdminRoutes(public, admin), appLogger, controller),
+		}
+	}
+
+	return HTTPServers{
+		NewHTTPServer(PublicHTTPServerName, &cfg.HTTP, public, appLogger, controller),
+		NewHTTPServer(AdminHTTPServerName, &cfg.Admin, admin, appLogger, controller),
+	}
+}
+
+// AdminOnlyPathPrefix marks admin routes, such as pprof, that expose the
+// process and are only served by a dedicated admin listener.
+const AdminOnlyPathPrefix = "/debug/"
+
+// withAdminRoutes serves the admin routes, except those under
+// AdminOnlyPathPrefix, from the public handler.
+func withAdminRoutes(public PublicHTTPHandler, admin AdminHTTPHandler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if h, pattern := admin.Handler(r); pattern != "" && !strings.HasPrefix(r.URL.Path, AdminOnlyPathPrefix) {
+			h.ServeHTTP(w, r)
+
+			return
+		}
+
+		public.ServeHTTP(w, r)
+	})
 }
 
 var _ HTTPServer = (*httpServer)(nil)
 
 type httpServer struct {
-	config  *httpServerConfig
+	name    string
+	config  *httpListenerConfig
 	handler http.Handler
 	se      *http.Server
+	ln      net.Listener
+	rawLn   net.Listener
 	logger  *slog.Logger
+	errCh   chan error
+	certs   *certReloader
 }
 
 var ErrorServerNotStarted = errors.New("server not started")
 
-func NewHTTPServer(cfg *httpServerConfig, handler http.Handler, appLogger AppLogger) *httpServer {
+func NewHTTPServer(
+	name string,
+	cfg *httpListenerConfig,
+	handler http.Handler,
+	appLogger AppLogger,
+	controller Controller,
+) *httpServer {
 	s := &httpServer{
+		name:    name,
 		config:  cfg,
 		handler: handler,
 		se:      nil,
-		logger:  appLogger.GetLogger(),
+		logger:  appLogger.GetLogger().With("component", "http-server", "server", name),
+		errCh:   make(chan error, 1),
 	}
 
+	controller.RegisterStartup("http-server/"+name, s.Start, WithPriority(httpServerPriority))
+	controller.RegisterShutdown("http-server/"+name, s.shutdown, WithPriority(httpServerPriority))
+
 	return s
 }
 
+func (s *httpServer) Name() string {
+	return s.name
+}
+
+func (s *httpServer) Addr() net.Addr {
+	if s.ln == nil {
+		return nil
+	}
+
+	return s.ln.Addr()
+}
+
+// Start binds the listener synchronously, so address and TLS errors are
+// returned to the caller, then serves in the background. Serve failures are
+// reported on Errors.
 func (s *httpServer) Start(ctx context.Context) error {
+	cfg := s.config
+
+	protocols, err := parseProtocols(cfg.Protocols)
+	if err != nil {
+		return err
+	}
+
 	s.se = &http.Server{
-		Addr:    s.config.HTTP.Addr,
-		Handler: otelhttp.NewHandler(NewRecoveryMiddleware(s.handler), "http-server"),
+		Addr:              cfg.Addr,
+		Handler:           otelhttp.NewHandler(NewRecoveryMiddleware(s.handler), "http-server/"+s.name),
+		ReadTimeout:       cfg.ReadTimeout,
+		ReadHeaderTimeout: cfg.ReadHeaderTimeout,
+		WriteTimeout:      cfg.WriteTimeout,
+		IdleTimeout:       cfg.IdleTimeout,
+		MaxHeaderBytes:    cfg.MaxHeaderBytes,
+		ErrorLog:          slog.NewLogLogger(s.logger.Handler(), slog.LevelWarn),
+		Protocols:         protocols,
+		HTTP2:             cfg.HTTP2.toHTTP(),
+	}
+
+	if cfg.TLS.Enabled {
+		certs, err := newCertReloader(&cfg.TLS, alpnProtocols(protocols), s.logger)
+		if err != nil {
+			return err
+		}
+
+		if err := certs.Watch(ctx); err != nil {
+			return err
+		}
+
+		s.certs = certs
+		s.se.TLSConfig = certs.TLSConfig()
+	}
+
+	socketMode, err := ParseSocketMode(cfg.SocketMode)
+	if err != nil {
+		_ = s.closeCerts()
+
+		return err
+	}
+
+	ln, err := inheritedListener(s.name)
+	if err == nil && ln == nil {
+		ln, err = Listen(ctx, cfg.Addr, WithSocketMode(socketMode))
+	} else if ln != nil {
+		s.logger.Info("HTTP server took over inherited listener")
+	}
+
+	if err != nil {
+		_ = s.closeCerts()
+
+		return fmt.Errorf("listen on %s: %w", cfg.Addr, err)
+	}
+
+	s.rawLn = ln
+
+	if s.se.TLSConfig != nil {
+		ln = tls.NewListener(ln, s.se.TLSConfig)
 	}
 
+	s.ln = ln
+
+	s.logger.Info("HTTP server listening",
+		"addr", ln.Addr().String(), "tls", cfg.TLS.Enabled, "protocols", protocols.String())
+
 	go func() {
-		if err := s.se.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
-			panic(err)
+		if err := s.se.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			s.logger.Error("HTTP server stopped", "error", err)
+			s.errCh <- fmt.Errorf("http server %s: %w", s.name, err)
 		}
 	}()
 
 	return nil
 }
 
+func (s *httpServer) Errors() <-chan error {
+	return s.errCh
+}
+
+func (s *httpServer) ListenerFile() (*os.File, error) {
+	if s.rawLn == nil {
+		return nil, ErrorServerNotStarted
+	}
+
+	return listenerFile(s.rawLn)
+}
+
 func (s *httpServer) Shutdown(ctx context.Context) error {
 	if s.se == nil {
 		return ErrorServerNotStarted
 	}
 
 	if err := s.se.Shutdown(ctx); err != nil {
+		return errors.Join(err, s.closeCerts())
+	}
+
+	return s.closeCerts()
+}
+
+// shutdown is the controller hook; a server that never started has nothing to stop.
+func (s *httpServer) shutdown(ctx context.Context) error {
+	if err := s.Shutdown(ctx); err != nil && !errors.Is(err, ErrorServerNotStarted) {
 		return err
 	}
 
 	return nil
 }
 
+func (s *httpServer) closeCerts() error {
+	if s.certs == nil {
+		return nil
+	}
+
+	return s.certs.Close()
+}
+
 type recoverMiddleware struct {
 	logger  slog.Logger
 	level   slog.Level
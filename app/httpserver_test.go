@@ -0,0 +1,44 @@
+package app_test
+
+import (
+	"application/app"
+	"context"
+	"net"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPServerBindError(t *testing.T) {
+	taken, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = taken.Close() })
+
+	a, _, servers := newRestartApp(t, map[string]any{"server.http.addr": taken.Addr().String()}, nil)
+
+	err = servers[0].Start(context.Background())
+	require.ErrorIs(t, err, syscall.EADDRINUSE, "the bind error is returned by Start")
+	assert.Nil(t, servers[0].Addr())
+
+	require.ErrorIs(t, a.Start(context.Background()), syscall.EADDRINUSE)
+}
+
+func TestHTTPServerServeError(t *testing.T) {
+	a, _, servers := newRestartApp(t, nil, nil)
+	require.NoError(t, a.Start(context.Background()))
+
+	require.NoError(t, app.CloseListener(servers[0]))
+
+	select {
+	case err := <-a.Errors():
+		require.ErrorIs(t, err, net.ErrClosed)
+		assert.Contains(t, err.Error(), "http server "+app.PublicHTTPServerName)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the serve error was not delivered on Errors")
+	}
+
+	require.NoError(t, a.Shutdown(context.Background()))
+}
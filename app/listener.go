@@ -0,0 +1,237 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+const (
+	unixScheme    = "unix://"
+	systemdScheme = "systemd://"
+)
+
+var (
+	ErrSocketInUse       = errors.New("unix socket is in use by another process")
+	ErrNotASocket        = errors.New("path exists and is not a unix socket")
+	ErrNoSystemdListener = errors.New("no matching listener passed by systemd")
+	ErrInvalidSocketMode = errors.New("invalid socket mode")
+	ErrListenerTaken     = errors.New("inherited listener already taken")
+
+	ErrListenerNotInheritable = errors.New("listener cannot be passed to another process")
+)
+
+const (
+	// EnvInheritedListeners names, colon separated and in descriptor order
+	// from fd 3, the HTTP servers whose listeners a restarting parent passed.
+	EnvInheritedListeners = "INHERITED_LISTENERS"
+	// EnvInheritedReadyFD is the pipe a restarted process writes to once ready.
+	EnvInheritedReadyFD = "INHERITED_READY_FD"
+)
+
+// listenFDsStart is the first file descriptor passed by systemd (SD_LISTEN_FDS_START)
+// or by a restarting parent.
+var listenFDsStart = 3
+
+// takenFDs records inherited descriptors already turned into listeners.
+var takenFDs sync.Map
+
+type listenOptions struct {
+	socketMode fs.FileMode
+}
+
+// ListenOption is a function option for Listen.
+type ListenOption func(*listenOptions)
+
+// WithSocketMode sets the permissions of a unix socket file.
+func WithSocketMode(mode fs.FileMode) ListenOption {
+	return func(o *listenOptions) {
+		o.socketMode = mode
+	}
+}
+
+// ParseSocketMode parses an octal file mode such as "0660". An empty string yields zero.
+func ParseSocketMode(mode string) (fs.FileMode, error) {
+	if mode == "" {
+		return 0, nil
+	}
+
+	m, err := strconv.ParseUint(mode, 8, 32)
+	if err != nil || m > uint64(fs.ModePerm) {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidSocketMode, mode)
+	}
+
+	return fs.FileMode(m), nil
+}
+
+// Listen opens the listener described by addr:
+//   - host:port listens on TCP,
+//   - unix:///path/to.sock listens on a unix socket, removing a stale socket file first,
+//   - systemd://name takes over the socket passed through LISTEN_FDS whose
+//     FileDescriptorName is name; a number selects the socket by position and
+//     an empty name selects the first one.
+func Listen(ctx context.Context, addr string, opts ...ListenOption) (net.Listener, error) {
+	options := new(listenOptions)
+	for _, o := range opts {
+		o(options)
+	}
+
+	switch {
+	case strings.HasPrefix(addr, unixScheme):
+		return listenUnix(ctx, strings.TrimPrefix(addr, unixScheme), options)
+	case strings.HasPrefix(addr, systemdScheme):
+		return listenSystemd(strings.TrimPrefix(addr, systemdScheme))
+	default:
+		return new(net.ListenConfig).Listen(ctx, "tcp", addr)
+	}
+}
+
+func listenUnix(ctx context.Context, path string, options *listenOptions) (net.Listener, error) {
+	if err := removeStaleSocket(ctx, path); err != nil {
+		return nil, err
+	}
+
+	ln, err := new(net.ListenConfig).Listen(ctx, "unix", path)
+	if err != nil {
+		return nil, err
+	}
+
+	if options.socketMode != 0 {
+		if err := os.Chmod(path, options.socketMode); err != nil {
+			_ = ln.Close()
+
+			return nil, err
+		}
+	}
+
+	return ln, nil
+}
+
+// removeStaleSocket deletes a socket file left behind by a process that is
+// no longer accepting on it. A live socket or a regular file is an error.
+func removeStaleSocket(ctx context.Context, path string) error {
+	info, err := os.Lstat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if info.Mode().Type() != fs.ModeSocket {
+		return fmt.Errorf("%w: %s", ErrNotASocket, path)
+	}
+
+	dialer := net.Dialer{Timeout: time.Second}
+
+	conn, err := dialer.DialContext(ctx, "unix", path)
+	if err == nil {
+		_ = conn.Close()
+
+		return fmt.Errorf("%w: %s", ErrSocketInUse, path)
+	}
+
+	if !errors.Is(err, syscall.ECONNREFUSED) {
+		return err
+	}
+
+	return os.Remove(path)
+}
+
+// listenSystemd implements the sd_listen_fds protocol.
+func listenSystemd(name string) (net.Listener, error) {
+	if pid, err := strconv.Atoi(os.Getenv("LISTEN_PID")); err != nil || pid != os.Getpid() {
+		return nil, fmt.Errorf("%w: LISTEN_PID does not match this process", ErrNoSystemdListener)
+	}
+
+	count, err := strconv.Atoi(os.Getenv("LISTEN_FDS"))
+	if err != nil || count <= 0 {
+		return nil, fmt.Errorf("%w: LISTEN_FDS is not set", ErrNoSystemdListener)
+	}
+
+	names := strings.Split(os.Getenv("LISTEN_FDNAMES"), ":")
+
+	index := -1
+
+	switch n, err := strconv.Atoi(name); {
+	case name == "":
+		index = 0
+	case err == nil:
+		index = n
+	default:
+		for i := 0; i < count && i < len(names); i++ {
+			if names[i] == name {
+				index = i
+
+				break
+			}
+		}
+	}
+
+	if index < 0 || index >= count {
+		return nil, fmt.Errorf("%w: %q", ErrNoSystemdListener, name)
+	}
+
+	fd := listenFDsStart + index
+
+	if _, taken := takenFDs.LoadOrStore(fd, true); taken {
+		return nil, fmt.Errorf("%w: fd %d", ErrListenerTaken, fd)
+	}
+
+	// FileListener duplicates the descriptor, so the inherited one is closed.
+	f := os.NewFile(uintptr(fd), fmt.Sprintf("systemd:%s", name))
+	defer f.Close()
+
+	return net.FileListener(f)
+}
+
+// inheritedListener returns the listener a restarting parent passed for the
+// named server, or nil when there is none.
+func inheritedListener(name string) (net.Listener, error) {
+	env := os.Getenv(EnvInheritedListeners)
+	if env == "" {
+		return nil, nil //nolint:nilnil
+	}
+
+	index := slices.Index(strings.Split(env, ":"), name)
+	if index < 0 {
+		return nil, nil //nolint:nilnil
+	}
+
+	fd := listenFDsStart + index
+
+	if _, taken := takenFDs.LoadOrStore(fd, true); taken {
+		return nil, fmt.Errorf("%w: fd %d", ErrListenerTaken, fd)
+	}
+
+	f := os.NewFile(uintptr(fd), "inherited:"+name)
+	defer f.Close()
+
+	return net.FileListener(f)
+}
+
+// listenerFile returns a duplicate of ln's descriptor for a new process.
+// A unix socket is no longer unlinked when ln closes, as the new process
+// keeps serving on its path.
+func listenerFile(ln net.Listener) (*os.File, error) {
+	if ul, ok := ln.(*net.UnixListener); ok {
+		ul.SetUnlinkOnClose(false)
+	}
+
+	filer, ok := ln.(interface{ File() (*os.File, error) })
+	if !ok {
+		return nil, fmt.Errorf("%w: %T", ErrListenerNotInheritable, ln)
+	}
+
+	return filer.File()
+}
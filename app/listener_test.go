@@ -0,0 +1,115 @@
+package app_test
+
+import (
+	"application/app"
+	"context"
+	"io/fs"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func assertAccepts(t *testing.T, ln net.Listener) {
+	t.Helper()
+
+	go func() {
+		conn, err := net.Dial(ln.Addr().Network(), ln.Addr().String())
+		if err == nil {
+			_ = conn.Close()
+		}
+	}()
+
+	conn, err := ln.Accept()
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+}
+
+func TestListenUnix(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.sock")
+
+	ln, err := app.Listen(context.Background(), "unix://"+path, app.WithSocketMode(0o600))
+	require.NoError(t, err)
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, fs.FileMode(0o600), info.Mode().Perm())
+
+	assertAccepts(t, ln)
+
+	_, err = app.Listen(context.Background(), "unix://"+path)
+	require.ErrorIs(t, err, app.ErrSocketInUse)
+
+	require.NoError(t, ln.Close())
+}
+
+func TestListenUnixStaleSocket(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.sock")
+
+	stale, err := net.ListenUnix("unix", &net.UnixAddr{Name: path, Net: "unix"})
+	require.NoError(t, err)
+	stale.SetUnlinkOnClose(false)
+	require.NoError(t, stale.Close())
+
+	ln, err := app.Listen(context.Background(), "unix://"+path)
+	require.NoError(t, err)
+	assertAccepts(t, ln)
+	require.NoError(t, ln.Close())
+}
+
+func TestListenUnixNotASocket(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.sock")
+	require.NoError(t, os.WriteFile(path, nil, 0o600))
+
+	_, err := app.Listen(context.Background(), "unix://"+path)
+	require.ErrorIs(t, err, app.ErrNotASocket)
+}
+
+func TestListenSystemd(t *testing.T) {
+	tcp, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	defer tcp.Close()
+
+	f, err := tcp.(*net.TCPListener).File()
+	require.NoError(t, err)
+
+	// Listen takes ownership of the inherited descriptor, so hand it a
+	// duplicate that f does not also close.
+	fd, err := syscall.Dup(int(f.Fd()))
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	defer app.SetListenFDsStart(fd)()
+
+	t.Setenv("LISTEN_PID", strconv.Itoa(os.Getpid()))
+	t.Setenv("LISTEN_FDS", "1")
+	t.Setenv("LISTEN_FDNAMES", "http")
+
+	_, err = app.Listen(context.Background(), "systemd://admin")
+	require.ErrorIs(t, err, app.ErrNoSystemdListener)
+
+	ln, err := app.Listen(context.Background(), "systemd://http")
+	require.NoError(t, err)
+	assert.Equal(t, tcp.Addr().String(), ln.Addr().String())
+
+	assertAccepts(t, ln)
+	require.NoError(t, ln.Close())
+
+	_, err = app.Listen(context.Background(), "systemd://http")
+	require.ErrorIs(t, err, app.ErrListenerTaken)
+}
+
+func TestParseSocketMode(t *testing.T) {
+	mode, err := app.ParseSocketMode("0660")
+	require.NoError(t, err)
+	assert.Equal(t, fs.FileMode(0o660), mode)
+
+	_, err = app.ParseSocketMode("999")
+	require.ErrorIs(t, err, app.ErrInvalidSocketMode)
+}
@@ -3,6 +3,7 @@ package app
 import (
 	"application/pkg/utils"
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"os"
@@ -19,13 +20,39 @@ func NewSlogLogger(appLogger AppLogger) *slog.Logger {
 
 type appLoggerConfig struct {
 	Slog struct {
-		Level    string `koanf:"level"`
-		Encoding string `koanf:"encoding"`
+		Level    string `koanf:"level"    doc:"debug, info, warn or error; applied live on reload"`
+		Encoding string `koanf:"encoding" doc:"text or json"`
 	} `koanf:"slog"`
 }
 
+func (c *appLoggerConfig) SetDefaults() {
+	c.Slog.Level = "info"
+	c.Slog.Encoding = "text"
+}
+
+func (c *appLoggerConfig) Validate() error {
+	var errs []error
+
+	if c.Slog.Level != "" {
+		var lvl slog.Level
+		if err := lvl.UnmarshalText([]byte(strings.ToLower(c.Slog.Level))); err != nil {
+			errs = append(errs, &FieldError{
+				Key: "slog.level",
+				Err: fmt.Errorf("%w %q, want debug, info, warn or error", ErrConfigInvalid, c.Slog.Level),
+			})
+		}
+	}
+
+	if c.Slog.Encoding != "" {
+		errs = append(errs, OneOf("slog.encoding", c.Slog.Encoding, "text", "json"))
+	}
+
+	return errors.Join(errs...)
+}
+
 func NewAppLoggerConfig(c *KConfig) (*appLoggerConfig, error) {
 	config := new(appLoggerConfig)
+	config.SetDefaults()
 
 	if err := c.Unmarshal("logger", config); err != nil {
 		return nil, err
@@ -44,15 +71,26 @@ type appLogger struct {
 
 	logger       *slog.Logger
 	loggerConfig *appLoggerConfig
+	level        *slog.LevelVar
 	handlers     []slog.Handler
 }
 
-func NewAppLogger(ctx context.Context, appConfig *appConfig, config *appLoggerConfig, otlp OTLP) (*appLogger, error) {
+func NewAppLogger(
+	ctx context.Context,
+	c *KConfig,
+	appConfig *appConfig,
+	config *appLoggerConfig,
+	otlp OTLP,
+) (*appLogger, error) {
+	level := new(slog.LevelVar)
+	level.Set(parseLevel(config.Slog.Level))
+
 	l := &appLogger{
 		loggerConfig: config,
 		logger:       nil,
+		level:        level,
 		handlers: []slog.Handler{
-			defaultHandler(config.Slog.Level, config.Slog.Encoding),
+			defaultHandler(level, config.Slog.Encoding),
 			otelslog.NewHandler(
 				fmt.Sprintf("otlp/%s", appConfig.Title),
 				otelslog.WithLoggerProvider(otlp.GetLoggerProvider()),
@@ -65,9 +103,19 @@ func NewAppLogger(ctx context.Context, appConfig *appConfig, config *appLoggerCo
 
 	logger.Info("Logger initialized", "level", config.Slog.Level, "encoding", config.Slog.Encoding)
 
+	c.Subscribe("logger.slog.level", l.reloadLevel)
+
 	return l, nil
 }
 
+// reloadLevel applies a changed logger.slog.level; the encoding needs a restart.
+func (l *appLogger) reloadLevel(_ context.Context, c *KConfig) {
+	level := c.String("logger.slog.level")
+	l.level.Set(parseLevel(level))
+
+	l.logger.Info("Logger level changed", "level", level)
+}
+
 func (l *appLogger) GetLogger() *slog.Logger {
 	return l.logger
 }
@@ -76,21 +124,26 @@ func (l *appLogger) AppendHandler(handler slog.Handler) {
 	l.handlers = append(l.handlers, handler)
 }
 
-func defaultHandler(level, encoder string) slog.Handler {
+// parseLevel parses a slog level name, falling back to info.
+func parseLevel(level string) slog.Level {
 	var lvl slog.Level
 	if err := lvl.UnmarshalText([]byte(strings.ToLower(level))); err != nil {
-		lvl = slog.LevelInfo
+		return slog.LevelInfo
 	}
 
+	return lvl
+}
+
+func defaultHandler(level slog.Leveler, encoder string) slog.Handler {
 	if strings.ToLower(encoder) == "json" {
 		return slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
 			AddSource: true,
-			Level:     lvl,
+			Level:     level,
 		})
 	}
 
 	return slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
 		AddSource: true,
-		Level:     lvl,
+		Level:     level,
 	})
 }
@@ -9,6 +9,7 @@ import (
 	"strconv"
 
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
@@ -38,8 +39,8 @@ const (
 type collectorConfig struct {
 	Exporters struct {
 		OTLP struct {
-			Endpoint string `koanf:"endpoint"`
-			Insecure bool   `koanf:"insecure"`
+			Endpoint string `koanf:"endpoint" doc:"gRPC endpoint of the OpenTelemetry collector, required when a signal is enabled"`
+			Insecure bool   `koanf:"insecure" doc:"disable TLS to the collector"`
 		} `koanf:"otlp"`
 	} `koanf:"exporters"`
 
@@ -56,6 +57,14 @@ type collectorConfig struct {
 	} `koanf:"logs"`
 }
 
+func (c *collectorConfig) Validate() error {
+	if c.Traces.Enabled || c.Metrics.Enabled || c.Logs.Enabled {
+		return Required("exporters.otlp.endpoint", c.Exporters.OTLP.Endpoint)
+	}
+
+	return nil
+}
+
 func NewCollectorConfig(ctx context.Context, c *KConfig) (*collectorConfig, error) {
 	config := new(collectorConfig)
 	if err := c.Unmarshal("collector", config); err != nil {
@@ -153,27 +162,27 @@ func (o *otlp) GetLoggerProvider() otellogapi.LoggerProvider {
 
 // initOTLPResource initializes the OTLP resource with default attributes.
 func (o *otlp) initOTLPResource(ctx context.Context) error {
-	host, port, err := net.SplitHostPort(o.httpConfig.HTTP.Addr)
-	if err != nil {
-		return err
+	attrs := []attribute.KeyValue{
+		otelsemconv.ServiceName(o.appConfig.Title),
+		otelsemconv.ServiceVersion(o.appConfig.Version),
+		otelsemconv.DeploymentEnvironmentName(o.appConfig.Environment),
 	}
 
-	p, err := strconv.Atoi(port)
-	if err != nil {
-		return err
+	// unix and systemd addresses have no host and port.
+	if host, port, err := net.SplitHostPort(o.httpConfig.HTTP.Addr); err == nil {
+		p, err := strconv.Atoi(port)
+		if err != nil {
+			return err
+		}
+
+		attrs = append(attrs, otelsemconv.SourceAddress(host), otelsemconv.SourcePort(p))
 	}
 
 	appResource, err := otelresouesdk.New(
 		ctx,
 		otelresouesdk.WithSchemaURL(otelsemconv.SchemaURL),
 
-		otelresouesdk.WithAttributes(
-			otelsemconv.ServiceName(o.appConfig.Title),
-			otelsemconv.ServiceVersion(o.appConfig.Version),
-			otelsemconv.DeploymentEnvironmentName(o.appConfig.Environment),
-			otelsemconv.SourceAddress(host),
-			otelsemconv.SourcePort(p),
-		),
+		otelresouesdk.WithAttributes(attrs...),
 		otelresouesdk.WithFromEnv(),      // pull attributes from OTEL_RESOURCE_ATTRIBUTES env var
 		otelresouesdk.WithProcess(),      // pull attributes from the current process
 		otelresouesdk.WithHost(),         // pull attributes from the host
@@ -334,27 +343,27 @@ func (o *otlp) startTraces(ctx context.Context) error {
 func (o *otlp) shutdown(ctx context.Context) error {
 	logger := o.logger.With("method", "shutdown", "version", o.appConfig.Version)
 
-	var err error
+	var errs error
 	if o.otlpTracer != nil {
 		if err := o.otlpTracer.Shutdown(ctx); err != nil {
-			err = errors.Join(err, err)
+			errs = errors.Join(errs, err)
 			logger.Error("failed to shutdown OTLP tracer provider", "error", err)
 		}
 	}
 
 	if o.otlpMeter != nil {
 		if err := o.otlpMeter.Shutdown(ctx); err != nil {
-			err = errors.Join(err, err)
+			errs = errors.Join(errs, err)
 			logger.Error("failed to shutdown OTLP meter provider", "error", err)
 		}
 	}
 
 	if o.otlpLogger != nil {
 		if err := o.otlpLogger.Shutdown(ctx); err != nil {
-			err = errors.Join(err, err)
+			errs = errors.Join(errs, err)
 			logger.Error("failed to shutdown OTLP logger provider", "error", err)
 		}
 	}
 
-	return err
+	return errs
 }
@@ -0,0 +1,111 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Protocol names accepted by server.<name>.protocols.
+const (
+	ProtocolHTTP1 = "http1"
+	// ProtocolHTTP2 is HTTP/2 over TLS, negotiated with ALPN.
+	ProtocolHTTP2 = "http2"
+	// ProtocolH2C is unencrypted HTTP/2 with prior knowledge.
+	ProtocolH2C = "h2c"
+	// ProtocolHTTP3 is reserved for a QUIC listener, which net/http does not provide yet.
+	ProtocolHTTP3 = "http3"
+)
+
+var (
+	ErrUnknownProtocol     = errors.New("unknown http protocol")
+	ErrUnsupportedProtocol = errors.New("unsupported http protocol")
+)
+
+// http2Config mirrors http.HTTP2Config. Zero values keep the Go defaults.
+type http2Config struct {
+	MaxConcurrentStreams          int           `koanf:"max_concurrent_streams"            doc:"0 keeps the Go default"`
+	MaxDecoderHeaderTableSize     int           `koanf:"max_decoder_header_table_size"`
+	MaxEncoderHeaderTableSize     int           `koanf:"max_encoder_header_table_size"`
+	MaxReadFrameSize              int           `koanf:"max_read_frame_size"`
+	MaxReceiveBufferPerConnection int           `koanf:"max_receive_buffer_per_connection"`
+	MaxReceiveBufferPerStream     int           `koanf:"max_receive_buffer_per_stream"`
+	SendPingTimeout               time.Duration `koanf:"send_ping_timeout"                 doc:"idle time before a ping is sent"`
+	PingTimeout                   time.Duration `koanf:"ping_timeout"                      doc:"how long to wait for a ping response"`
+	WriteByteTimeout              time.Duration `koanf:"write_byte_timeout"`
+}
+
+func (c *http2Config) Validate() error {
+	return errors.Join(
+		NonNegative("max_concurrent_streams", c.MaxConcurrentStreams),
+		NonNegative("max_decoder_header_table_size", c.MaxDecoderHeaderTableSize),
+		NonNegative("max_encoder_header_table_size", c.MaxEncoderHeaderTableSize),
+		NonNegative("max_read_frame_size", c.MaxReadFrameSize),
+		NonNegative("max_receive_buffer_per_connection", c.MaxReceiveBufferPerConnection),
+		NonNegative("max_receive_buffer_per_stream", c.MaxReceiveBufferPerStream),
+		NonNegative("send_ping_timeout", c.SendPingTimeout),
+		NonNegative("ping_timeout", c.PingTimeout),
+		NonNegative("write_byte_timeout", c.WriteByteTimeout),
+	)
+}
+
+func (c *http2Config) toHTTP() *http.HTTP2Config {
+	return &http.HTTP2Config{
+		MaxConcurrentStreams:          c.MaxConcurrentStreams,
+		MaxDecoderHeaderTableSize:     c.MaxDecoderHeaderTableSize,
+		MaxEncoderHeaderTableSize:     c.MaxEncoderHeaderTableSize,
+		MaxReadFrameSize:              c.MaxReadFrameSize,
+		MaxReceiveBufferPerConnection: c.MaxReceiveBufferPerConnection,
+		MaxReceiveBufferPerStream:     c.MaxReceiveBufferPerStream,
+		SendPingTimeout:               c.SendPingTimeout,
+		PingTimeout:                   c.PingTimeout,
+		WriteByteTimeout:              c.WriteByteTimeout,
+	}
+}
+
+// parseProtocols converts protocol names to an http.Protocols set.
+// No names means HTTP/1 and HTTP/2 over TLS, the net/http default.
+func parseProtocols(names []string) (*http.Protocols, error) {
+	p := new(http.Protocols)
+
+	if len(names) == 0 {
+		p.SetHTTP1(true)
+		p.SetHTTP2(true)
+
+		return p, nil
+	}
+
+	for _, name := range names {
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case ProtocolHTTP1:
+			p.SetHTTP1(true)
+		case ProtocolHTTP2:
+			p.SetHTTP2(true)
+		case ProtocolH2C:
+			p.SetUnencryptedHTTP2(true)
+		case ProtocolHTTP3:
+			return nil, fmt.Errorf("%w: %s", ErrUnsupportedProtocol, name)
+		default:
+			return nil, fmt.Errorf("%w: %q", ErrUnknownProtocol, name)
+		}
+	}
+
+	return p, nil
+}
+
+// alpnProtocols returns the ALPN identifiers a TLS listener advertises for p.
+func alpnProtocols(p *http.Protocols) []string {
+	var protos []string
+
+	if p.HTTP2() {
+		protos = append(protos, "h2")
+	}
+
+	if p.HTTP1() {
+		protos = append(protos, "http/1.1")
+	}
+
+	return protos
+}
@@ -0,0 +1,232 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// DefaultRestartTimeout bounds how long Restart waits for the new process to become ready.
+const DefaultRestartTimeout = 30 * time.Second
+
+var (
+	ErrRestartChildExited = errors.New("new process exited before becoming ready")
+	ErrRestartTimeout     = errors.New("new process did not become ready in time")
+)
+
+// Restart starts a new instance of the current executable with the same
+// arguments, handing it every HTTP listener, and waits until the readiness
+// checks of the new process pass. The caller then shuts this process down;
+// connections are never refused because the listening sockets stay open in
+// the new process.
+// If the new process fails to start, this process keeps serving.
+func (a *app) Restart(ctx context.Context) error {
+	logger := a.logger.With("method", "Restart")
+
+	path, err := os.Executable()
+	if err != nil {
+		return err
+	}
+
+	files := make([]*os.File, 0, len(a.httpServers)+1)
+	names := make([]string, 0, len(a.httpServers))
+
+	defer func() {
+		for _, f := range files {
+			_ = f.Close()
+		}
+	}()
+
+	for _, s := range a.httpServers {
+		f, err := s.ListenerFile()
+		if err != nil {
+			return fmt.Errorf("http server %s: %w", s.Name(), err)
+		}
+
+		files = append(files, f)
+		names = append(names, s.Name())
+	}
+
+	ready, readyW, err := os.Pipe()
+	if err != nil {
+		return err
+	}
+	defer ready.Close()
+
+	files = append(files, readyW)
+
+	env := withoutEnv(os.Environ(), EnvInheritedListeners, EnvInheritedReadyFD)
+	env = append(env,
+		EnvInheritedListeners+"="+strings.Join(names, ":"),
+		EnvInheritedReadyFD+"="+strconv.Itoa(listenFDsStart+len(names)),
+	)
+
+	cmd := exec.CommandContext(context.WithoutCancel(ctx), path, os.Args[1:]...) //nolint:gosec
+	cmd.Env = env
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.ExtraFiles = files
+
+	err = cmd.Start()
+
+	for _, f := range files[:len(names)] {
+		if err := setNonblock(f); err != nil {
+			logger.Warn("Failed to restore non-blocking listener", "error", err)
+		}
+	}
+
+	if err != nil {
+		return err
+	}
+
+	// Only the child may hold the write end, so its exit unblocks the read.
+	_ = readyW.Close()
+	files = files[:len(files)-1]
+
+	logger.Info("Started new process, waiting for it to become ready", "pid", cmd.Process.Pid, "path", path)
+
+	timeout := a.appConfig.RestartTimeout
+	if timeout <= 0 {
+		timeout = DefaultRestartTimeout
+	}
+
+	_ = ready.SetReadDeadline(time.Now().Add(timeout))
+
+	if _, err := ready.Read(make([]byte, 1)); err != nil {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+
+		if errors.Is(err, os.ErrDeadlineExceeded) {
+			return ErrRestartTimeout
+		}
+
+		return fmt.Errorf("%w: %s", ErrRestartChildExited, cmd.ProcessState)
+	}
+
+	go func() { _ = cmd.Wait() }()
+
+	logger.Info("New process is ready", "pid", cmd.Process.Pid)
+
+	return nil
+}
+
+// readyPollInterval is how often a restarted process runs its readiness
+// checks until they pass.
+const readyPollInterval = 100 * time.Millisecond
+
+// notifyParentWhenReady tells a restarting parent that this process is ready
+// once the readiness checks pass, so the parent keeps serving until then. The
+// parent kills this process when it is not ready within restart_timeout.
+func (a *app) notifyParentWhenReady(ctx context.Context) {
+	if os.Getenv(EnvInheritedReadyFD) == "" {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(readyPollInterval)
+		defer ticker.Stop()
+
+		for !a.ready(ctx) {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+
+		if err := notifyParent(); err != nil {
+			a.logger.Warn("Failed to notify restarting parent", "error", err)
+
+			return
+		}
+
+		a.logger.Info("Notified restarting parent")
+	}()
+}
+
+// ready reports whether the readiness probe passes: no critical readiness
+// check is failing and the application is not draining.
+func (a *app) ready(ctx context.Context) bool {
+	if a.controller.IsDraining() {
+		return false
+	}
+
+	for _, check := range a.controller.GetHealthzReadiness() {
+		if check.Severity() == SeverityCritical && check.Check(ctx).Failing {
+			return false
+		}
+	}
+
+	return true
+}
+
+// notifyParent tells a restarting parent that this process is ready.
+func notifyParent() error {
+	env := os.Getenv(EnvInheritedReadyFD)
+	if env == "" {
+		return nil
+	}
+
+	_ = os.Unsetenv(EnvInheritedReadyFD)
+
+	fd, err := strconv.Atoi(env)
+	if err != nil {
+		return fmt.Errorf("invalid %s: %w", EnvInheritedReadyFD, err)
+	}
+
+	f := os.NewFile(uintptr(fd), "ready")
+	defer f.Close()
+
+	_, err = f.Write([]byte{1})
+
+	return err
+}
+
+// setNonblock puts the socket of f back in non-blocking mode. Passing f to a
+// new process leaves it blocking, and the listener it duplicates shares the
+// mode, so Accept and Shutdown of this process would block.
+func setNonblock(f *os.File) error {
+	raw, err := f.SyscallConn()
+	if err != nil {
+		return err
+	}
+
+	var nonblockErr error
+
+	if err := raw.Control(func(fd uintptr) {
+		nonblockErr = syscall.SetNonblock(int(fd), true)
+	}); err != nil {
+		return err
+	}
+
+	return nonblockErr
+}
+
+func withoutEnv(env []string, keys ...string) []string {
+	out := make([]string, 0, len(env))
+
+	for _, kv := range env {
+		drop := false
+
+		for _, key := range keys {
+			if strings.HasPrefix(kv, key+"=") {
+				drop = true
+
+				break
+			}
+		}
+
+		if !drop {
+			out = append(out, kv)
+		}
+	}
+
+	return out
+}
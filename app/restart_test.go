@@ -0,0 +1,228 @@
+package app_test
+
+import (
+	"application/app"
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/knadh/koanf/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	// envRestartChild selects what the test binary does when started by Restart.
+	envRestartChild = "RESTART_TEST_CHILD"
+	// envRestartReadyFile is the file the child's readiness check waits for.
+	envRestartReadyFile = "RESTART_TEST_READY_FILE"
+)
+
+// newRestartApp builds an app serving its pid on a single HTTP server.
+// GET /quit closes quit.
+func newRestartApp(t *testing.T, settings map[string]any, quit chan struct{}) (app.Application, app.Controller, app.HTTPServers) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	k := koanf.New(".")
+	require.NoError(t, k.Set("server.http.addr", "127.0.0.1:0"))
+
+	for key, value := range settings {
+		require.NoError(t, k.Set(key, value))
+	}
+
+	config := app.NewKConfig(k)
+
+	appConfig, err := app.NewAppConfig(ctx, config)
+	require.NoError(t, err)
+
+	httpConfig, err := app.NewHTTPServerConfig(ctx, config)
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = io.WriteString(w, strconv.Itoa(os.Getpid()))
+	})
+	mux.HandleFunc("GET /quit", func(http.ResponseWriter, *http.Request) {
+		close(quit)
+	})
+
+	controller := app.NewController()
+	servers := app.NewHTTPServers(httpConfig, mux, http.NewServeMux(), discardLogger{}, controller)
+
+	return app.NewApp(nil, config, appConfig, httpConfig, servers, discardLogger{}, controller), controller, servers
+}
+
+// TestRestartChild is the process Restart starts; it only runs when started by
+// one of the restart tests.
+func TestRestartChild(t *testing.T) {
+	mode := os.Getenv(envRestartChild)
+	if mode == "" {
+		t.Skip("started by the restart tests")
+	}
+
+	if mode == "exit" {
+		os.Exit(1)
+	}
+
+	quit := make(chan struct{})
+	a, controller, _ := newRestartApp(t, nil, quit)
+
+	controller.RegisterHealthz("ready-file", func(context.Context) error {
+		_, err := os.Stat(os.Getenv(envRestartReadyFile))
+
+		return err
+	})
+
+	if err := a.Start(context.Background()); err != nil {
+		os.Exit(2)
+	}
+
+	select {
+	case <-quit:
+	case <-time.After(10 * time.Second):
+	}
+
+	_ = a.Shutdown(context.Background())
+
+	// Exit before the testing package reports to the parent's stdout.
+	os.Exit(0)
+}
+
+// restartAs makes Restart start TestRestartChild in the given mode.
+func restartAs(t *testing.T, mode string) {
+	t.Helper()
+
+	args := os.Args
+	os.Args = []string{args[0], "-test.run=^TestRestartChild$"}
+
+	t.Cleanup(func() { os.Args = args })
+	t.Setenv(envRestartChild, mode)
+}
+
+func get(t *testing.T, url string) (string, error) {
+	t.Helper()
+
+	resp, err := http.Get(url)
+	if err != nil {
+		return "", err
+	}
+
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+
+	return string(body), err
+}
+
+func TestRestart(t *testing.T) {
+	readyFile := t.TempDir() + "/ready"
+
+	restartAs(t, "serve")
+	t.Setenv(envRestartReadyFile, readyFile)
+
+	ctx := context.Background()
+	a, _, servers := newRestartApp(t, map[string]any{"app.restart_timeout": "10s"}, make(chan struct{}))
+	require.NoError(t, a.Start(ctx))
+
+	url := "http://" + servers[0].Addr().String()
+
+	restarted := make(chan error, 1)
+
+	go func() { restarted <- a.Restart(ctx) }()
+
+	select {
+	case err := <-restarted:
+		require.FailNow(t, "Restart returned before the new process was ready", "error: %v", err)
+	case <-time.After(500 * time.Millisecond):
+	}
+
+	require.NoError(t, os.WriteFile(readyFile, nil, 0o600))
+	require.NoError(t, <-restarted)
+
+	require.NoError(t, servers[0].Shutdown(ctx))
+
+	pid, err := get(t, url)
+	require.NoError(t, err, "the new process serves on the inherited listener")
+	assert.NotEqual(t, strconv.Itoa(os.Getpid()), pid)
+
+	_, _ = get(t, url+"/quit")
+}
+
+func TestRestartTimeout(t *testing.T) {
+	restartAs(t, "serve")
+	t.Setenv(envRestartReadyFile, t.TempDir()+"/never")
+
+	ctx := context.Background()
+	a, _, servers := newRestartApp(t, map[string]any{"app.restart_timeout": "300ms"}, make(chan struct{}))
+	require.NoError(t, a.Start(ctx))
+
+	t.Cleanup(func() { _ = servers[0].Shutdown(context.Background()) })
+
+	require.ErrorIs(t, a.Restart(ctx), app.ErrRestartTimeout)
+
+	pid, err := get(t, "http://"+servers[0].Addr().String())
+	require.NoError(t, err, "the old process keeps serving")
+	assert.Equal(t, strconv.Itoa(os.Getpid()), pid)
+}
+
+func TestRestartChildExited(t *testing.T) {
+	restartAs(t, "exit")
+
+	ctx := context.Background()
+	a, _, servers := newRestartApp(t, nil, make(chan struct{}))
+	require.NoError(t, a.Start(ctx))
+
+	t.Cleanup(func() { _ = servers[0].Shutdown(context.Background()) })
+
+	require.ErrorIs(t, a.Restart(ctx), app.ErrRestartChildExited)
+}
+
+func TestHTTPServerInheritedListener(t *testing.T) {
+	ctx := context.Background()
+	_, _, servers := newRestartApp(t, nil, make(chan struct{}))
+	require.NoError(t, servers[0].Start(ctx))
+
+	t.Cleanup(func() { _ = servers[0].Shutdown(context.Background()) })
+
+	f, err := servers[0].ListenerFile()
+	require.NoError(t, err)
+
+	// The inherited descriptor is closed once taken over, so hand over a
+	// duplicate that f does not also close. f.Fd would make the listening
+	// socket blocking.
+	raw, err := f.SyscallConn()
+	require.NoError(t, err)
+
+	var fd int
+
+	require.NoError(t, raw.Control(func(f uintptr) { fd, err = syscall.Dup(int(f)) }))
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	defer app.SetListenFDsStart(fd - 1)()
+
+	t.Setenv(app.EnvInheritedListeners, "admin:"+app.PublicHTTPServerName)
+
+	_, _, inherited := newRestartApp(t, nil, make(chan struct{}))
+	require.NoError(t, inherited[0].Start(ctx))
+
+	t.Cleanup(func() { _ = inherited[0].Shutdown(context.Background()) })
+
+	assert.Equal(t, servers[0].Addr().String(), inherited[0].Addr().String(),
+		"the server takes over the listener named after it")
+
+	_, _, again := newRestartApp(t, nil, make(chan struct{}))
+	require.ErrorIs(t, again[0].Start(ctx), app.ErrListenerTaken)
+
+	require.NoError(t, servers[0].Shutdown(ctx))
+
+	_, err = get(t, "http://"+inherited[0].Addr().String())
+	require.NoError(t, err)
+}
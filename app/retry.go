@@ -0,0 +1,113 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"time"
+)
+
+const (
+	DefaultRetryDeadline        = 30 * time.Second
+	DefaultRetryInitialInterval = 500 * time.Millisecond
+	DefaultRetryMaxInterval     = 10 * time.Second
+	DefaultRetryMultiplier      = 2
+)
+
+var ErrRetryDeadline = errors.New("retry deadline exceeded")
+
+// RetryConfig configures Retry. Embed it in the config of a component that
+// connects on start, under a "retry" key.
+type RetryConfig struct {
+	Deadline        time.Duration `koanf:"deadline"         doc:"give up after this long, 0 tries once"`
+	InitialInterval time.Duration `koanf:"initial_interval" doc:"wait before the second attempt"`
+	MaxInterval     time.Duration `koanf:"max_interval"     doc:"upper bound of the wait between attempts"`
+	Multiplier      float64       `koanf:"multiplier"       doc:"growth of the wait after each attempt"`
+	// StartNotReady is read by the component, not by Retry.
+	StartNotReady bool `koanf:"start_not_ready" doc:"start anyway when the deadline passes, failing readiness until connected"`
+}
+
+func (c *RetryConfig) SetDefaults() {
+	c.Deadline = DefaultRetryDeadline
+	c.InitialInterval = DefaultRetryInitialInterval
+	c.MaxInterval = DefaultRetryMaxInterval
+	c.Multiplier = DefaultRetryMultiplier
+}
+
+func (c *RetryConfig) Validate() error {
+	errs := []error{
+		NonNegative("deadline", c.Deadline),
+		NonNegative("initial_interval", c.InitialInterval),
+		NonNegative("max_interval", c.MaxInterval),
+	}
+
+	if c.Multiplier < 1 {
+		errs = append(errs, &FieldError{Key: "multiplier", Err: fmt.Errorf("%w: %v is below 1", ErrConfigOutOfRange, c.Multiplier)})
+	}
+
+	return errors.Join(errs...)
+}
+
+// Retry calls fn until it succeeds, ctx is done or config.Deadline passed,
+// waiting an exponentially growing interval with jitter between attempts.
+// The attempts run under the deadline too, so a hanging fn is cancelled when
+// it passes. Each failed attempt is logged. The returned error wraps the last
+// one of fn.
+func Retry(ctx context.Context, logger *slog.Logger, config RetryConfig, fn func(ctx context.Context) error) error {
+	deadline := time.Now().Add(config.Deadline)
+	interval := config.InitialInterval
+
+	attemptCtx := ctx
+
+	if config.Deadline > 0 {
+		var cancel context.CancelFunc
+
+		attemptCtx, cancel = context.WithDeadline(ctx, deadline)
+		defer cancel()
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := fn(attemptCtx)
+		if err == nil {
+			if attempt > 1 {
+				logger.InfoContext(ctx, "Connected after retrying", "attempt", attempt)
+			}
+
+			return nil
+		}
+
+		if ctx.Err() != nil {
+			return errors.Join(ctx.Err(), err)
+		}
+
+		wait := jitter(interval)
+
+		if attemptCtx.Err() != nil || time.Now().Add(wait).After(deadline) {
+			logger.ErrorContext(ctx, "Giving up", "attempt", attempt, "deadline", config.Deadline, "error", err)
+
+			return fmt.Errorf("%w after %d attempts: %w", ErrRetryDeadline, attempt, err)
+		}
+
+		logger.WarnContext(ctx, "Attempt failed, retrying", "attempt", attempt, "wait", wait, "error", err)
+
+		select {
+		case <-ctx.Done():
+			return errors.Join(ctx.Err(), err)
+		case <-time.After(wait):
+		}
+
+		interval = min(time.Duration(float64(interval)*config.Multiplier), config.MaxInterval)
+	}
+}
+
+// jitter returns a random duration between d/2 and d, so replicas started
+// together do not retry in lockstep.
+func jitter(d time.Duration) time.Duration {
+	if d <= 1 {
+		return d
+	}
+
+	return d/2 + rand.N(d/2) //nolint:gosec,mnd
+}
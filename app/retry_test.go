@@ -0,0 +1,91 @@
+package app_test
+
+import (
+	"application/app"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetry(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	config := app.RetryConfig{
+		Deadline:        time.Second,
+		InitialInterval: time.Millisecond,
+		MaxInterval:     4 * time.Millisecond,
+		Multiplier:      2,
+	}
+	errDown := errors.New("down")
+
+	attempts := 0
+	err := app.Retry(context.Background(), logger, config, func(context.Context) error {
+		if attempts++; attempts < 5 {
+			return errDown
+		}
+
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 5, attempts)
+
+	config.Deadline = 20 * time.Millisecond
+	attempts = 0
+
+	start := time.Now()
+	err = app.Retry(context.Background(), logger, config, func(context.Context) error {
+		attempts++
+
+		return errDown
+	})
+	require.ErrorIs(t, err, app.ErrRetryDeadline)
+	require.ErrorIs(t, err, errDown)
+	assert.Greater(t, attempts, 1)
+	assert.Less(t, time.Since(start), 100*time.Millisecond)
+
+	config.Deadline = 0
+	attempts = 0
+	require.ErrorIs(t, app.Retry(context.Background(), logger, config, func(context.Context) error {
+		attempts++
+
+		return errDown
+	}), errDown)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestRetryCancelsHangingAttempt(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	config := app.RetryConfig{
+		Deadline:        50 * time.Millisecond,
+		InitialInterval: time.Millisecond,
+		MaxInterval:     time.Millisecond,
+		Multiplier:      1,
+	}
+
+	start := time.Now()
+	err := app.Retry(context.Background(), logger, config, func(ctx context.Context) error {
+		<-ctx.Done()
+
+		return ctx.Err()
+	})
+	require.ErrorIs(t, err, app.ErrRetryDeadline)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), time.Second)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(10*time.Millisecond, cancel)
+
+	config.Deadline = time.Minute
+	err = app.Retry(ctx, logger, config, func(ctx context.Context) error {
+		<-ctx.Done()
+
+		return ctx.Err()
+	})
+	require.ErrorIs(t, err, context.Canceled)
+	require.NotErrorIs(t, err, app.ErrRetryDeadline)
+}
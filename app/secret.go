@@ -0,0 +1,168 @@
+package app
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/go-viper/mapstructure/v2"
+)
+
+var (
+	ErrSecretUnresolved = errors.New("unresolved secret reference")
+	ErrSecretEnvNotSet  = errors.New("env var is not set")
+	ErrSecretBase64     = errors.New("invalid base64")
+)
+
+// SecretResolver returns the value a secret reference points to. ref is the
+// reference without the prefix the resolver is registered for.
+type SecretResolver interface {
+	Resolve(ref string) (string, error)
+}
+
+// SecretResolverFunc adapts a function to SecretResolver.
+type SecretResolverFunc func(ref string) (string, error)
+
+func (f SecretResolverFunc) Resolve(ref string) (string, error) {
+	return f(ref)
+}
+
+type secretResolver struct {
+	prefix   string
+	resolver SecretResolver
+}
+
+var (
+	secretResolversMu sync.RWMutex
+	secretResolvers   []secretResolver
+)
+
+func init() {
+	RegisterSecretResolver("file://", SecretResolverFunc(resolveFileSecret))
+	RegisterSecretResolver("env://", SecretResolverFunc(resolveEnvSecret))
+	RegisterSecretResolver("base64:", SecretResolverFunc(resolveBase64Secret))
+}
+
+// RegisterSecretResolver resolves config string values starting with prefix,
+// such as "vault://", through r when a config section is unmarshalled or
+// validated. Registering a prefix again replaces its resolver.
+func RegisterSecretResolver(prefix string, r SecretResolver) {
+	secretResolversMu.Lock()
+	defer secretResolversMu.Unlock()
+
+	for i, s := range secretResolvers {
+		if s.prefix == prefix {
+			secretResolvers[i].resolver = r
+
+			return
+		}
+	}
+
+	secretResolvers = append(secretResolvers, secretResolver{prefix: prefix, resolver: r})
+}
+
+// secretPrefix returns the registered prefix value starts with, or "" when
+// value is not a secret reference. The longest matching prefix wins.
+func secretPrefix(value string) (string, SecretResolver) {
+	secretResolversMu.RLock()
+	defer secretResolversMu.RUnlock()
+
+	var match secretResolver
+
+	for _, s := range secretResolvers {
+		if strings.HasPrefix(value, s.prefix) && len(s.prefix) > len(match.prefix) {
+			match = s
+		}
+	}
+
+	return match.prefix, match.resolver
+}
+
+// resolveSecret returns value, or what it points to when it is a secret
+// reference. Errors name the prefix only, never the resolved value.
+func resolveSecret(value string) (string, error) {
+	prefix, r := secretPrefix(value)
+	if r == nil {
+		return value, nil
+	}
+
+	resolved, err := r.Resolve(strings.TrimPrefix(value, prefix))
+	if err != nil {
+		return "", fmt.Errorf("%w %s...: %w", ErrSecretUnresolved, prefix, err)
+	}
+
+	return resolved, nil
+}
+
+// secretHookFunc resolves secret references while decoding strings.
+func secretHookFunc() mapstructure.DecodeHookFuncKind {
+	return func(from, _ reflect.Kind, data any) (any, error) {
+		if from != reflect.String {
+			return data, nil
+		}
+
+		return resolveSecret(reflect.ValueOf(data).String())
+	}
+}
+
+// resolveFileSecret reads a file, as mounted by Docker or Kubernetes secrets.
+// A single trailing newline is dropped.
+func resolveFileSecret(path string) (string, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(strings.TrimSuffix(string(b), "\n"), "\r"), nil
+}
+
+func resolveEnvSecret(name string) (string, error) {
+	value, ok := os.LookupEnv(name)
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrSecretEnvNotSet, name)
+	}
+
+	return value, nil
+}
+
+func resolveBase64Secret(encoded string) (string, error) {
+	b, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrSecretBase64, err)
+	}
+
+	return string(b), nil
+}
+
+// Secret is a config value that is never printed: formatting, logging and
+// marshalling it yield Redacted. Use Value to read it.
+type Secret string
+
+func (s Secret) Value() string {
+	return string(s)
+}
+
+func (s Secret) String() string {
+	if s == "" {
+		return ""
+	}
+
+	return Redacted
+}
+
+func (s Secret) GoString() string {
+	return s.String()
+}
+
+func (s Secret) LogValue() slog.Value {
+	return slog.StringValue(s.String())
+}
+
+func (s Secret) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
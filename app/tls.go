@@ -0,0 +1,262 @@
+package app
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+type tlsConfig struct {
+	Enabled  bool   `koanf:"enabled"   doc:"serve HTTPS"`
+	CertFile string `koanf:"cert_file" doc:"PEM certificate chain, required when enabled"`
+	KeyFile  string `koanf:"key_file"  doc:"PEM private key, required when enabled"`
+	// ClientCAFile is a PEM bundle used to verify client certificates (mTLS).
+	ClientCAFile string `koanf:"client_ca_file" doc:"PEM bundle used to verify client certificates (mTLS)"`
+	// ClientAuth is one of none, request, require, verify_if_given or require_and_verify.
+	// It defaults to require_and_verify when ClientCAFile is set and none otherwise.
+	ClientAuth string `koanf:"client_auth" doc:"none, request, require, verify_if_given or require_and_verify"`
+	// MinVersion is 1.2 or 1.3, defaulting to 1.2.
+	MinVersion string `koanf:"min_version" doc:"1.2 or 1.3"`
+	// Watch reloads the certificate, key and CA bundle when they change on disk.
+	Watch bool `koanf:"watch" doc:"reload the certificate, key and CA bundle when they change on disk"`
+}
+
+var (
+	ErrTLSInvalidClientAuth = errors.New("invalid tls client_auth")
+	ErrTLSInvalidMinVersion = errors.New("invalid tls min_version")
+	ErrTLSInvalidClientCA   = errors.New("no certificates found in tls client_ca_file")
+)
+
+// Validate checks the settings; the files are read when the server starts.
+func (c *tlsConfig) Validate() error {
+	_, clientAuthErr := c.clientAuthType()
+	_, minVersionErr := c.minVersion()
+
+	errs := []error{
+		Invalid("client_auth", clientAuthErr),
+		Invalid("min_version", minVersionErr),
+	}
+
+	if c.Enabled {
+		errs = append(errs, Required("cert_file", c.CertFile), Required("key_file", c.KeyFile))
+	}
+
+	return errors.Join(errs...)
+}
+
+func (c *tlsConfig) clientAuthType() (tls.ClientAuthType, error) {
+	switch strings.ToLower(c.ClientAuth) {
+	case "":
+		if c.ClientCAFile != "" {
+			return tls.RequireAndVerifyClientCert, nil
+		}
+
+		return tls.NoClientCert, nil
+	case "none":
+		return tls.NoClientCert, nil
+	case "request":
+		return tls.RequestClientCert, nil
+	case "require":
+		return tls.RequireAnyClientCert, nil
+	case "verify_if_given":
+		return tls.VerifyClientCertIfGiven, nil
+	case "require_and_verify":
+		return tls.RequireAndVerifyClientCert, nil
+	default:
+		return tls.NoClientCert, fmt.Errorf("%w: %q", ErrTLSInvalidClientAuth, c.ClientAuth)
+	}
+}
+
+func (c *tlsConfig) minVersion() (uint16, error) {
+	switch c.MinVersion {
+	case "", "1.2":
+		return tls.VersionTLS12, nil
+	case "1.3":
+		return tls.VersionTLS13, nil
+	default:
+		return 0, fmt.Errorf("%w: %q", ErrTLSInvalidMinVersion, c.MinVersion)
+	}
+}
+
+// certReloader serves the server certificate and client CA pool from disk and
+// swaps them in place when the files change, so rotated certificates (e.g. by
+// cert-manager) are picked up without a restart.
+type certReloader struct {
+	mu sync.RWMutex
+
+	config    *tlsConfig
+	logger    *slog.Logger
+	base      *tls.Config
+	cert      *tls.Certificate
+	clientCAs *x509.CertPool
+	watcher   *fsnotify.Watcher
+	done      chan struct{}
+}
+
+func newCertReloader(config *tlsConfig, nextProtos []string, logger *slog.Logger) (*certReloader, error) {
+	clientAuth, err := config.clientAuthType()
+	if err != nil {
+		return nil, err
+	}
+
+	minVersion, err := config.minVersion()
+	if err != nil {
+		return nil, err
+	}
+
+	r := &certReloader{
+		config: config,
+		logger: logger.With("component", "tls"),
+		base: &tls.Config{
+			MinVersion: minVersion,
+			ClientAuth: clientAuth,
+			NextProtos: nextProtos,
+		},
+	}
+
+	if err := r.reload(); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// TLSConfig returns a config resolving the current certificate and client CAs on every handshake.
+func (r *certReloader) TLSConfig() *tls.Config {
+	cfg := r.base.Clone()
+	cfg.GetConfigForClient = func(*tls.ClientHelloInfo) (*tls.Config, error) {
+		r.mu.RLock()
+		defer r.mu.RUnlock()
+
+		c := r.base.Clone()
+		c.Certificates = []tls.Certificate{*r.cert}
+		c.ClientCAs = r.clientCAs
+
+		return c, nil
+	}
+
+	return cfg
+}
+
+// reload reads the files from disk. On failure the previous material is kept.
+func (r *certReloader) reload() error {
+	cert, err := tls.LoadX509KeyPair(r.config.CertFile, r.config.KeyFile)
+	if err != nil {
+		return fmt.Errorf("load tls key pair: %w", err)
+	}
+
+	var pool *x509.CertPool
+
+	if r.config.ClientCAFile != "" {
+		pem, err := os.ReadFile(r.config.ClientCAFile)
+		if err != nil {
+			return fmt.Errorf("read tls client_ca_file: %w", err)
+		}
+
+		pool = x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("%w: %s", ErrTLSInvalidClientCA, r.config.ClientCAFile)
+		}
+	}
+
+	r.mu.Lock()
+	r.cert = &cert
+	r.clientCAs = pool
+	r.mu.Unlock()
+
+	return nil
+}
+
+// Watch starts watching the directories holding the TLS files. Directories
+// rather than files are watched because secret mounts replace files through
+// symlink swaps.
+func (r *certReloader) Watch(ctx context.Context) error {
+	if !r.config.Watch {
+		return nil
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+
+	dirs := map[string]bool{}
+
+	for _, f := range []string{r.config.CertFile, r.config.KeyFile, r.config.ClientCAFile} {
+		if f == "" {
+			continue
+		}
+
+		dir := filepath.Dir(f)
+		if dirs[dir] {
+			continue
+		}
+
+		dirs[dir] = true
+
+		if err := watcher.Add(dir); err != nil {
+			_ = watcher.Close()
+
+			return fmt.Errorf("watch %s: %w", dir, err)
+		}
+	}
+
+	r.watcher = watcher
+	r.done = make(chan struct{})
+
+	go r.loop(ctx)
+
+	return nil
+}
+
+func (r *certReloader) loop(ctx context.Context) {
+	defer close(r.done)
+
+	for {
+		select {
+		case event, ok := <-r.watcher.Events:
+			if !ok {
+				return
+			}
+
+			if event.Op == fsnotify.Chmod {
+				continue
+			}
+
+			if err := r.reload(); err != nil {
+				r.logger.WarnContext(ctx, "TLS reload failed, keeping previous certificates", "error", err)
+
+				continue
+			}
+
+			r.logger.InfoContext(ctx, "TLS certificates reloaded", "event", event.String())
+		case err, ok := <-r.watcher.Errors:
+			if !ok {
+				return
+			}
+
+			r.logger.WarnContext(ctx, "TLS watcher error", "error", err)
+		}
+	}
+}
+
+// Close stops the file watcher.
+func (r *certReloader) Close() error {
+	if r.watcher == nil {
+		return nil
+	}
+
+	err := r.watcher.Close()
+	<-r.done
+
+	return err
+}
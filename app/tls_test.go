@@ -0,0 +1,250 @@
+package app_test
+
+import (
+	"application/app"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"log/slog"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/knadh/koanf/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type discardLogger struct{}
+
+func (discardLogger) GetLogger() *slog.Logger      { return slog.New(slog.NewTextHandler(io.Discard, nil)) }
+func (discardLogger) AppendHandler(_ slog.Handler) {}
+
+// testCA issues certificates for the TLS tests.
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pool *x509.CertPool
+	pem  []byte
+}
+
+func newTestCA(t *testing.T) *testCA {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(cert)
+
+	return &testCA{
+		cert: cert,
+		key:  key,
+		pool: pool,
+		pem:  pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+	}
+}
+
+// issue returns the PEM certificate and key of a leaf named commonName.
+func (ca *testCA) issue(t *testing.T, commonName string, usage x509.ExtKeyUsage) (certPEM, keyPEM []byte) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1)},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+// writeServerCert writes a server certificate named commonName to dir.
+func (ca *testCA) writeServerCert(t *testing.T, dir, commonName string) (certFile, keyFile string) {
+	t.Helper()
+
+	certPEM, keyPEM := ca.issue(t, commonName, x509.ExtKeyUsageServerAuth)
+	certFile, keyFile = filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+
+	require.NoError(t, os.WriteFile(keyFile, keyPEM, 0o600))
+	require.NoError(t, os.WriteFile(certFile, certPEM, 0o600))
+
+	return certFile, keyFile
+}
+
+// startTLSServer serves 200 OK over HTTPS with the given server.http.tls settings.
+func startTLSServer(t *testing.T, settings map[string]any) string {
+	t.Helper()
+
+	ctx := context.Background()
+
+	k := koanf.New(".")
+	require.NoError(t, k.Set("server.http.addr", "127.0.0.1:0"))
+	require.NoError(t, k.Set("server.http.tls.enabled", true))
+
+	for key, value := range settings {
+		require.NoError(t, k.Set("server.http.tls."+key, value))
+	}
+
+	cfg, err := app.NewHTTPServerConfig(ctx, app.NewKConfig(k))
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	servers := app.NewHTTPServers(cfg, mux, http.NewServeMux(), discardLogger{}, app.NewController())
+	require.Len(t, servers, 1)
+
+	require.NoError(t, servers[0].Start(ctx))
+	t.Cleanup(func() { _ = servers[0].Shutdown(context.Background()) })
+
+	return "https://" + servers[0].Addr().String()
+}
+
+// tlsClient trusts ca and handshakes on every request.
+func tlsClient(ca *testCA, configure func(*tls.Config)) *http.Client {
+	cfg := &tls.Config{RootCAs: ca.pool, MinVersion: tls.VersionTLS12}
+	if configure != nil {
+		configure(cfg)
+	}
+
+	return &http.Client{
+		Transport: &http.Transport{TLSClientConfig: cfg, DisableKeepAlives: true},
+		Timeout:   5 * time.Second,
+	}
+}
+
+// serverName returns the common name of the certificate served at url.
+func serverName(t *testing.T, client *http.Client, url string) string {
+	t.Helper()
+
+	resp, err := client.Get(url)
+	require.NoError(t, err)
+
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.NotNil(t, resp.TLS)
+
+	return resp.TLS.PeerCertificates[0].Subject.CommonName
+}
+
+func TestHTTPServerTLS(t *testing.T) {
+	ca := newTestCA(t)
+	certFile, keyFile := ca.writeServerCert(t, t.TempDir(), "server")
+
+	url := startTLSServer(t, map[string]any{"cert_file": certFile, "key_file": keyFile})
+
+	assert.Equal(t, "server", serverName(t, tlsClient(ca, nil), url))
+
+	_, err := tlsClient(newTestCA(t), nil).Get(url)
+	require.Error(t, err, "a client not trusting the CA fails the handshake")
+}
+
+func TestHTTPServerTLSMinVersion(t *testing.T) {
+	ca := newTestCA(t)
+	certFile, keyFile := ca.writeServerCert(t, t.TempDir(), "server")
+
+	url := startTLSServer(t, map[string]any{"cert_file": certFile, "key_file": keyFile, "min_version": "1.3"})
+
+	resp, err := tlsClient(ca, nil).Get(url)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, uint16(tls.VersionTLS13), resp.TLS.Version)
+
+	_, err = tlsClient(ca, func(c *tls.Config) { c.MaxVersion = tls.VersionTLS12 }).Get(url)
+	require.Error(t, err)
+}
+
+func TestHTTPServerMutualTLS(t *testing.T) {
+	ca := newTestCA(t)
+	dir := t.TempDir()
+	certFile, keyFile := ca.writeServerCert(t, dir, "server")
+
+	caFile := filepath.Join(dir, "ca.crt")
+	require.NoError(t, os.WriteFile(caFile, ca.pem, 0o600))
+
+	url := startTLSServer(t, map[string]any{
+		"cert_file":      certFile,
+		"key_file":       keyFile,
+		"client_ca_file": caFile,
+	})
+
+	_, err := tlsClient(ca, nil).Get(url)
+	require.Error(t, err, "client_auth defaults to require_and_verify with a client CA")
+
+	clientCert, clientKey := ca.issue(t, "client", x509.ExtKeyUsageClientAuth)
+	pair, err := tls.X509KeyPair(clientCert, clientKey)
+	require.NoError(t, err)
+
+	client := tlsClient(ca, func(c *tls.Config) { c.Certificates = []tls.Certificate{pair} })
+	assert.Equal(t, "server", serverName(t, client, url))
+
+	otherCert, otherKey := newTestCA(t).issue(t, "stranger", x509.ExtKeyUsageClientAuth)
+	other, err := tls.X509KeyPair(otherCert, otherKey)
+	require.NoError(t, err)
+
+	_, err = tlsClient(ca, func(c *tls.Config) { c.Certificates = []tls.Certificate{other} }).Get(url)
+	require.Error(t, err, "a client certificate of another CA is rejected")
+}
+
+func TestHTTPServerTLSReload(t *testing.T) {
+	ca := newTestCA(t)
+	dir := t.TempDir()
+	certFile, keyFile := ca.writeServerCert(t, dir, "first")
+
+	url := startTLSServer(t, map[string]any{"cert_file": certFile, "key_file": keyFile, "watch": true})
+	client := tlsClient(ca, nil)
+
+	require.Equal(t, "first", serverName(t, client, url))
+
+	ca.writeServerCert(t, dir, "second")
+
+	require.Eventually(t, func() bool {
+		return serverName(t, client, url) == "second"
+	}, 5*time.Second, 10*time.Millisecond, "the rewritten certificate is served without a restart")
+}
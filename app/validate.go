@@ -0,0 +1,296 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-viper/mapstructure/v2"
+	"github.com/knadh/koanf/v2"
+)
+
+var (
+	ErrConfigRequired   = errors.New("value is required")
+	ErrConfigOutOfRange = errors.New("value is out of range")
+	ErrConfigInvalid    = errors.New("invalid value")
+)
+
+// ConfigValidator is implemented by config structs that check their own values.
+// Problems are returned as FieldErrors, joined with errors.Join.
+type ConfigValidator interface {
+	Validate() error
+}
+
+// FieldError is a problem with one config key.
+type FieldError struct {
+	Key string
+	Err error
+}
+
+func (e *FieldError) Error() string {
+	return e.Key + ": " + e.Err.Error()
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+type configSection struct {
+	key string
+	// newConfig returns a *T holding its defaults.
+	newConfig func() any
+}
+
+var (
+	configSectionsMu sync.Mutex
+	configSections   []configSection
+)
+
+func init() {
+	RegisterConfig[appConfig]("app")
+	RegisterConfig[appLoggerConfig]("logger")
+	RegisterConfig[httpServerConfig]("server")
+	RegisterConfig[collectorConfig]("collector")
+}
+
+// RegisterConfig declares that key is decoded into a T. Every registered
+// section is checked when the config loads or reloads, and a T implementing
+// ConfigValidator validates itself. Keys T does not declare, and keys outside
+// every registered section, are unknown. A T implementing ConfigDefaulter
+// provides the defaults of DefaultConfigYAML.
+func RegisterConfig[T any](key string) {
+	configSectionsMu.Lock()
+	defer configSectionsMu.Unlock()
+
+	configSections = append(configSections, configSection{
+		key: key,
+		newConfig: func() any {
+			cfg := new(T)
+			if d, ok := any(cfg).(ConfigDefaulter); ok {
+				d.SetDefaults()
+			}
+
+			return cfg
+		},
+	})
+}
+
+// ValidateConfig checks every registered section of k and joins all problems
+// into one error. Unknown keys are not problems; see UnknownConfigKeys.
+func ValidateConfig(k *koanf.Koanf) error {
+	var errs []error
+
+	for _, s := range registeredSections() {
+		sectionErrs, _ := validateSection(k, s.key, s.newConfig())
+		errs = append(errs, sectionErrs...)
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	sort.SliceStable(errs, func(i, j int) bool { return errorKey(errs[i]) < errorKey(errs[j]) })
+
+	return &ConfigError{Errs: errs}
+}
+
+// UnknownConfigKeys returns, sorted, the keys of k no registered section
+// declares. They are ignored, so they are reported as warnings rather than
+// failing the config: older deployments may still set removed keys.
+func UnknownConfigKeys(k *koanf.Koanf) []string {
+	sections := registeredSections()
+
+	var unknown []string
+
+	for _, s := range sections {
+		_, sectionUnknown := validateSection(k, s.key, s.newConfig())
+		unknown = append(unknown, sectionUnknown...)
+	}
+
+	for _, key := range k.Keys() {
+		if key := unknownKey(sections, key); key != "" {
+			unknown = append(unknown, key)
+		}
+	}
+
+	slices.Sort(unknown)
+
+	return slices.Compact(unknown)
+}
+
+func registeredSections() []configSection {
+	configSectionsMu.Lock()
+	defer configSectionsMu.Unlock()
+
+	return slices.Clone(configSections)
+}
+
+// unknownKey returns the outermost part of key no section uses, or "" when
+// a section covers key.
+func unknownKey(sections []configSection, key string) string {
+	parts := strings.Split(key, ".")
+
+	for i := range parts {
+		prefix := strings.Join(parts[:i+1], ".")
+
+		if slices.ContainsFunc(sections, func(s configSection) bool { return keyMatches(s.key, prefix) }) {
+			return ""
+		}
+
+		if !slices.ContainsFunc(sections, func(s configSection) bool { return keyMatches(prefix, s.key) }) {
+			return prefix
+		}
+	}
+
+	return ""
+}
+
+// ConfigError lists every problem found in a config, one per line.
+type ConfigError struct {
+	Errs []error
+}
+
+func (e *ConfigError) Error() string {
+	lines := make([]string, 0, len(e.Errs))
+	for _, err := range e.Errs {
+		lines = append(lines, "  "+err.Error())
+	}
+
+	return fmt.Sprintf("%s (%d problems):\n%s", ErrInvalidConfig, len(e.Errs), strings.Join(lines, "\n"))
+}
+
+func (e *ConfigError) Unwrap() []error {
+	return append([]error{ErrInvalidConfig}, e.Errs...)
+}
+
+// validateSection decodes key into cfg and runs cfg's own validation. It
+// returns the problems, their keys prefixed with key, and the keys cfg does
+// not declare.
+func validateSection(k *koanf.Koanf, key string, cfg any) (errs []error, unknown []string) {
+	var md mapstructure.Metadata
+
+	err := k.UnmarshalWithConf(key, cfg, unmarshalConf(&md))
+	if err != nil {
+		return []error{&FieldError{Key: key, Err: err}}, nil
+	}
+
+	for _, unused := range md.Unused {
+		unknown = append(unknown, key+"."+unused)
+	}
+
+	v, ok := cfg.(ConfigValidator)
+	if !ok {
+		return nil, unknown
+	}
+
+	for _, err := range flattenErrors(v.Validate()) {
+		var fe *FieldError
+		if errors.As(err, &fe) {
+			err = &FieldError{Key: key + "." + fe.Key, Err: fe.Err}
+		}
+
+		errs = append(errs, err)
+	}
+
+	return errs, unknown
+}
+
+// unmarshalConf decodes like koanf's Unmarshal, resolving secret references
+// first. md, when not nil, collects the keys that were not decoded.
+func unmarshalConf(md *mapstructure.Metadata) koanf.UnmarshalConf {
+	return koanf.UnmarshalConf{
+		DecoderConfig: &mapstructure.DecoderConfig{
+			DecodeHook: mapstructure.ComposeDecodeHookFunc(
+				secretHookFunc(),
+				mapstructure.StringToTimeDurationHookFunc(),
+				mapstructure.TextUnmarshallerHookFunc()),
+			Metadata:         md,
+			WeaklyTypedInput: true,
+		},
+	}
+}
+
+func flattenErrors(err error) []error {
+	if err == nil {
+		return nil
+	}
+
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		return []error{err}
+	}
+
+	var errs []error
+	for _, e := range joined.Unwrap() {
+		errs = append(errs, flattenErrors(e)...)
+	}
+
+	return errs
+}
+
+func errorKey(err error) string {
+	var fe *FieldError
+	if errors.As(err, &fe) {
+		return fe.Key
+	}
+
+	return ""
+}
+
+// PrefixErrors prefixes the keys of the FieldErrors in err with prefix, for
+// config structs validating a nested struct.
+func PrefixErrors(prefix string, err error) error {
+	errs := flattenErrors(err)
+
+	for i, e := range errs {
+		var fe *FieldError
+		if errors.As(e, &fe) {
+			errs[i] = &FieldError{Key: prefix + "." + fe.Key, Err: fe.Err}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Required reports an empty value.
+func Required(key, value string) error {
+	if value == "" {
+		return &FieldError{Key: key, Err: ErrConfigRequired}
+	}
+
+	return nil
+}
+
+// OneOf reports a value, compared case-insensitively, that is not allowed.
+func OneOf(key, value string, allowed ...string) error {
+	if slices.Contains(allowed, strings.ToLower(value)) {
+		return nil
+	}
+
+	return &FieldError{
+		Key: key,
+		Err: fmt.Errorf("%w %q, want one of %s", ErrConfigInvalid, value, strings.Join(allowed, ", ")),
+	}
+}
+
+// NonNegative reports a negative number or duration.
+func NonNegative[T int | time.Duration](key string, value T) error {
+	if value < 0 {
+		return &FieldError{Key: key, Err: fmt.Errorf("%w: %v is negative", ErrConfigOutOfRange, value)}
+	}
+
+	return nil
+}
+
+// Invalid reports err, typically from parsing the value, for key.
+func Invalid(key string, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &FieldError{Key: key, Err: err}
+}
@@ -3,7 +3,6 @@ package app
 import "github.com/google/wire"
 
 var AppProviderSet = wire.NewSet(
-	NewRunTimeFlags,
 	NewKoanfConfig,
 
 	NewController,
@@ -18,8 +17,7 @@ var AppProviderSet = wire.NewSet(
 	wire.Bind(new(OTLP), new(*otlp)),
 
 	NewHTTPServerConfig,
-	NewHTTPServer,
-	wire.Bind(new(HTTPServer), new(*httpServer)),
+	NewHTTPServers,
 
 	NewAppConfig,
 	NewApp,
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"application/app"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+const configUsage = `Usage: app config <validate|print|defaults> [flags]
+`
+
+// config runs the config subcommands, which load the config without starting anything.
+func config(args []string) int {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, configUsage)
+
+		return 2 //nolint:mnd
+	}
+
+	fs := flag.NewFlagSet("config "+args[0], flag.ExitOnError)
+	flags := app.NewRunTimeFlags(fs)
+
+	switch args[0] {
+	case "validate":
+		_ = fs.Parse(args[1:])
+
+		c, err := app.NewKoanfConfig(flags)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+
+			return 1
+		}
+
+		for _, key := range c.UnknownKeys() {
+			fmt.Fprintf(os.Stderr, "warning: unknown key %s is ignored\n", key)
+		}
+
+		fmt.Fprintf(os.Stdout, "%s is valid\n", strings.Join(c.Sources(), ", "))
+
+		return 0
+	case "print":
+		_ = fs.Parse(args[1:])
+
+		c, err := app.NewKoanfConfig(flags)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+
+			return 1
+		}
+
+		return write(c.RedactedYAML())
+	case "defaults":
+		_ = fs.Parse(args[1:])
+
+		return write(app.DefaultConfigYAML())
+	default:
+		fmt.Fprintf(os.Stderr, "unknown config command %q\n\n%s", args[0], configUsage)
+
+		return 2 //nolint:mnd
+	}
+}
+
+func write(b []byte, err error) int {
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+
+		return 1
+	}
+
+	if _, err := os.Stdout.Write(b); err != nil {
+		return 1
+	}
+
+	return 0
+}
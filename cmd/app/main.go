@@ -1,21 +1,76 @@
 package main
 
 import (
+	"application/app"
 	"context"
+	"flag"
+	"fmt"
 	"log/slog"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 )
 
+const usage = `Usage: app <command> [flags]
+
+Commands:
+  serve             run the application (default)
+  config validate   check the config and report every problem
+  config print      print the effective config, secrets redacted
+  config defaults   print every known key with its default value
+  migrate up        apply every pending migration
+  migrate down      revert the last applied migration
+  migrate goto N    migrate up or down to version N, 0 reverts every migration
+  migrate status    print the applied version and the known migrations
+  migrate force N   record version N as applied and clean, after fixing a failed migration
+
+Run app <command> -h for the flags of a command.
+`
+
 // main function.
 func main() {
+	os.Exit(run(os.Args[1:]))
+}
+
+// run dispatches args to a command and returns the exit code.
+func run(args []string) int {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		return serve(args)
+	}
+
+	switch args[0] {
+	case "serve":
+		return serve(args[1:])
+	case "config":
+		return config(args[1:])
+	case "migrate":
+		return migrate(args[1:])
+	case "help", "-h", "--help":
+		fmt.Fprint(os.Stdout, usage)
+
+		return 0
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n%s", args[0], usage)
+
+		return 2 //nolint:mnd
+	}
+}
+
+// serve runs the application until it is stopped by a signal or fails.
+func serve(args []string) int {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	flags := app.NewRunTimeFlags(fs)
+	_ = fs.Parse(args)
+
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	app, err := wireApp(ctx)
+	app, err := wireApp(ctx, flags)
 	if err != nil {
-		panic(err)
+		fmt.Fprintln(os.Stderr, err)
+
+		return 1
 	}
 
 	logger := app.GetLogger().With("component", "main")
@@ -23,13 +78,72 @@ func main() {
 
 	logger.Info("app starting...")
 
+	quit := make(chan os.Signal, 1)
+	// SIGUSR2 hands the listeners to a freshly started binary, then stops this one.
+	// SIGHUP reloads the config.
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM, syscall.SIGUSR2, syscall.SIGHUP)
+
+	clean := true
+
 	if err := app.Start(ctx); err != nil {
-		panic(err)
+		logger.Error("app failed to start", "error", err)
+
+		clean = false
+	} else {
+		clean = wait(ctx, app, quit)
 	}
-	defer app.Shutdown(ctx)
 
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-	sig := <-quit
-	logger.Info("app stopping...", "signal", sig)
+	if err := app.Shutdown(ctx); err != nil {
+		logger.Error("app stopped with errors", "error", err)
+
+		clean = false
+	}
+
+	if !clean {
+		return 1
+	}
+
+	logger.Info("app stopped")
+
+	return 0
+}
+
+// wait blocks until the app should stop and reports whether it stops cleanly.
+func wait(ctx context.Context, a app.Application, quit <-chan os.Signal) bool {
+	logger := a.GetLogger().With("component", "main")
+
+	for {
+		select {
+		case sig := <-quit:
+			if sig == syscall.SIGHUP {
+				logger.Info("app reloading config...", "signal", sig)
+
+				_ = a.ReloadConfig(ctx)
+
+				continue
+			}
+
+			if sig != syscall.SIGUSR2 {
+				logger.Info("app stopping...", "signal", sig)
+
+				return true
+			}
+
+			logger.Info("app restarting...", "signal", sig)
+
+			if err := a.Restart(ctx); err != nil {
+				logger.Error("app restart failed, still serving", "error", err)
+
+				continue
+			}
+
+			logger.Info("app handed over to new process, stopping...")
+
+			return true
+		case err := <-a.Errors():
+			logger.Error("app failed, stopping...", "error", err)
+
+			return false
+		}
+	}
 }
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"application/app"
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// failingApp is an app.Application whose Errors are given by the test.
+type failingApp struct {
+	app.Application
+
+	errCh chan error
+}
+
+func (a *failingApp) Errors() <-chan error    { return a.errCh }
+func (a *failingApp) GetLogger() *slog.Logger { return slog.New(slog.DiscardHandler) }
+
+func TestWaitStopsOnAppError(t *testing.T) {
+	a := &failingApp{errCh: make(chan error, 1)}
+	a.errCh <- errors.New("http server http: accept failed")
+
+	assert.False(t, wait(context.Background(), a, make(chan os.Signal)), "a serve error stops the app uncleanly")
+
+	quit := make(chan os.Signal, 1)
+	quit <- syscall.SIGTERM
+
+	assert.True(t, wait(context.Background(), &failingApp{errCh: make(chan error)}, quit))
+}
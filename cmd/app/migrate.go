@@ -0,0 +1,132 @@
+package main
+
+import (
+	"application/app"
+	"application/internal/datasource"
+	"application/migrations"
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"text/tabwriter"
+)
+
+const migrateUsage = `Usage: app migrate <up|down|goto N|status|force N> [flags]
+`
+
+// migrate runs the migrate subcommands against datasource.postgres.
+func migrate(args []string) int {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, migrateUsage)
+
+		return 2 //nolint:mnd
+	}
+
+	command, args := args[0], args[1:]
+
+	var version uint64
+
+	switch command {
+	case "up", "down", "status":
+	case "goto", "force":
+		if len(args) == 0 {
+			fmt.Fprintf(os.Stderr, "migrate %s needs a version\n\n%s", command, migrateUsage)
+
+			return 2 //nolint:mnd
+		}
+
+		v, err := strconv.ParseUint(args[0], 10, 64)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "invalid version %q\n", args[0])
+
+			return 2 //nolint:mnd
+		}
+
+		version, args = v, args[1:]
+	default:
+		fmt.Fprintf(os.Stderr, "unknown migrate command %q\n\n%s", command, migrateUsage)
+
+		return 2 //nolint:mnd
+	}
+
+	fs := flag.NewFlagSet("migrate "+command, flag.ExitOnError)
+	flags := app.NewRunTimeFlags(fs)
+	_ = fs.Parse(args)
+
+	if err := runMigrate(context.Background(), flags, command, version); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+
+		return 1
+	}
+
+	return 0
+}
+
+func runMigrate(ctx context.Context, flags *app.RunTimeFlags, command string, version uint64) error {
+	c, err := app.NewKoanfConfig(flags)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := datasource.NewPostgresConfig(c)
+	if err != nil {
+		return err
+	}
+
+	db, err := datasource.OpenPostgres(cfg)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+
+	if err := app.Retry(ctx, logger.With("action", "connect"), cfg.Retry, db.PingContext); err != nil {
+		return err
+	}
+
+	migrator, err := datasource.NewMigrator(db, migrations.FS, logger)
+	if err != nil {
+		return err
+	}
+
+	switch command {
+	case "up":
+		return migrator.Up(ctx)
+	case "down":
+		return migrator.Down(ctx)
+	case "goto":
+		return migrator.Goto(ctx, version)
+	case "force":
+		return migrator.Force(ctx, version)
+	default:
+		status, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+
+		return printStatus(status)
+	}
+}
+
+func printStatus(status datasource.MigrationStatus) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0) //nolint:mnd
+	fmt.Fprintln(w, "VERSION\tNAME\tSTATE")
+
+	for _, m := range status.Migrations {
+		state := "pending"
+
+		switch {
+		case m.Version == status.Version && status.Dirty:
+			state = "dirty"
+		case m.Version <= status.Version:
+			state = "applied"
+		}
+
+		fmt.Fprintf(w, "%d\t%s\t%s\n", m.Version, m.Name, state)
+	}
+
+	return w.Flush()
+}
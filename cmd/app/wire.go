@@ -19,6 +19,7 @@ import (
 
 func wireApp(
 	ctx context.Context,
+	flags *app.RunTimeFlags,
 ) (app.Application, error) {
 	panic(wire.Build(
 		app.AppProviderSet,
@@ -27,5 +28,6 @@ func wireApp(
 		service.ServerProviderSet,
 		handler.HandlerProviderSet,
 		repo.RepoProvider,
+		wire.Bind(new(biz.Transactor), new(*datasource.TxManager)),
 	))
 }
@@ -19,9 +19,8 @@ import (
 
 // Injectors from wire.go:
 
-func wireApp(ctx context.Context) (app.Application, error) {
-	runTimeFlags := app.NewRunTimeFlags()
-	kConfig, err := app.NewKoanfConfig(runTimeFlags)
+func wireApp(ctx context.Context, flags *app.RunTimeFlags) (app.Application, error) {
+	kConfig, err := app.NewKoanfConfig(flags)
 	if err != nil {
 		return nil, err
 	}
@@ -46,27 +45,36 @@ func wireApp(ctx context.Context) (app.Application, error) {
 	if err != nil {
 		return nil, err
 	}
-	appLogger, err := app.NewAppLogger(ctx, appConfig, appLoggerConfig, otlp)
+	appLogger, err := app.NewAppLogger(ctx, kConfig, appConfig, appLoggerConfig, otlp)
 	if err != nil {
 		return nil, err
 	}
 	logger := app.NewSlogLogger(appLogger)
 	serveMux := http.NewServeMux()
 	healthz := biz.NewHealthz(logger, controller)
-	healthzHandler := handler.NewMuxHealthzHandler(healthz, logger, serveMux)
-	postgresDB, err := datasource.NewPostgresDB(ctx, logger, controller)
+	adminMux := service.NewAdminMux()
+	healthzHandler := handler.NewMuxHealthzHandler(healthz, logger, adminMux)
+	postgresDB, err := datasource.NewPostgresDB(ctx, logger, controller, kConfig)
 	if err != nil {
 		return nil, err
 	}
 	placeholder := repo.NewPlaceholder(logger, postgresDB)
-	bizPlaceholder := biz.NewPlaceholder(logger, placeholder, postgresDB)
+	txManager, err := datasource.NewTxManager(logger, postgresDB)
+	if err != nil {
+		return nil, err
+	}
+	bizPlaceholder := biz.NewPlaceholder(logger, placeholder, txManager)
 	handlerPlaceholder := handler.NewPlaceholder(logger, serveMux, bizPlaceholder)
 	v := handler.NewServiceList(healthzHandler, handlerPlaceholder)
-	httpHandler, err := service.NewHTTPHandler(ctx, logger, serveMux, v...)
+	publicHTTPHandler, err := service.NewHTTPHandler(ctx, logger, serveMux, v...)
+	if err != nil {
+		return nil, err
+	}
+	adminHTTPHandler, err := service.NewAdminHandler(ctx, logger, adminMux)
 	if err != nil {
 		return nil, err
 	}
-	httpServer := app.NewHTTPServer(httpServerConfig, httpHandler, appLogger)
-	appApp := app.NewApp(runTimeFlags, appConfig, httpServer, appLogger, controller)
+	httpServers := app.NewHTTPServers(httpServerConfig, publicHTTPHandler, adminHTTPHandler, appLogger, controller)
+	appApp := app.NewApp(flags, kConfig, appConfig, httpServerConfig, httpServers, appLogger, controller)
 	return appApp, nil
 }
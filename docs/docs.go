@@ -26,7 +26,7 @@ const docTemplate = `{
     "paths": {
         "/apis/mocks/placeholders": {
             "get": {
-                "description": "Retrieve a list of all placeholders.",
+                "description": "Retrieve a page of placeholders. Pass the next_cursor of a page as cursor, with the same sort and order, to get the next one.",
                 "consumes": [
                     "application/json"
                 ],
@@ -37,6 +37,63 @@ const docTemplate = `{
                     "Placeholders"
                 ],
                 "summary": "List placeholders",
+                "parameters": [
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "created_at",
+                            "name"
+                        ],
+                        "type": "string",
+                        "default": "created_at",
+                        "description": "Sort field",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "default": "asc",
+                        "description": "Sort order",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Name starts with",
+                        "name": "name_prefix",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Name contains",
+                        "name": "name_contains",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Count the matching placeholders",
+                        "name": "total",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "ok",
@@ -44,6 +101,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.PlaceholderListResponse"
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -160,8 +223,8 @@ const docTemplate = `{
                 "summary": "Update a placeholder",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Placeholder ID",
+                        "type": "string",
+                        "description": "Placeholder UUID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -217,8 +280,8 @@ const docTemplate = `{
                 "summary": "Delete a placeholder",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Placeholder ID",
+                        "type": "string",
+                        "description": "Placeholder UUID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -251,21 +314,40 @@ const docTemplate = `{
         },
         "/healthz/liveness": {
             "get": {
-                "description": "Check the liveness of the service",
+                "description": "Check the liveness of the service. Every check is reported as application/health+json unless verbose is false.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "application/health+json"
                 ],
                 "tags": [
                     "healthz"
                 ],
                 "summary": "Healthz Liveness",
                 "operationId": "healthz-liveness",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "default": true,
+                        "description": "Report every check",
+                        "name": "verbose",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
-                        "description": "ok"
+                        "description": "pass",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HealthResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "fail",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HealthResponse"
+                        }
                     }
                 }
             }
@@ -286,23 +368,42 @@ const docTemplate = `{
                 }
             }
         },
-        "/healthz/rediness": {
+        "/healthz/readiness": {
             "get": {
-                "description": "Check the readiness of the service",
+                "description": "Check the readiness of the service. Every check is reported as application/health+json unless verbose is false.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "application/health+json"
                 ],
                 "tags": [
                     "healthz"
                 ],
                 "summary": "Healthz Readiness",
                 "operationId": "healthz-rediness",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "default": true,
+                        "description": "Report every check",
+                        "name": "verbose",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
-                        "description": "ok"
+                        "description": "pass",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HealthResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "fail or draining",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HealthResponse"
+                        }
                     }
                 }
             }
@@ -328,6 +429,46 @@ const docTemplate = `{
                     }
                 }
             }
+        },
+        "/healthz/startup": {
+            "get": {
+                "description": "Check that the service finished starting. Every check is reported as application/health+json unless verbose is false.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/health+json"
+                ],
+                "tags": [
+                    "healthz"
+                ],
+                "summary": "Healthz Startup",
+                "operationId": "healthz-startup",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "default": true,
+                        "description": "Report every check",
+                        "name": "verbose",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "pass",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HealthResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "fail or starting",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HealthResponse"
+                        }
+                    }
+                }
+            }
         }
     },
     "definitions": {
@@ -355,23 +496,80 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.HealthCheckResponse": {
+            "type": "object",
+            "properties": {
+                "componentId": {
+                    "type": "string"
+                },
+                "observedUnit": {
+                    "type": "string"
+                },
+                "observedValue": {
+                    "type": "number"
+                },
+                "output": {
+                    "type": "string"
+                },
+                "severity": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "time": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.HealthResponse": {
+            "type": "object",
+            "properties": {
+                "checks": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "$ref": "#/definitions/dto.HealthCheckResponse"
+                        }
+                    }
+                },
+                "output": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
         "dto.PlaceholderListResponse": {
             "type": "object",
             "properties": {
                 "count": {
                     "type": "integer"
                 },
+                "next_cursor": {
+                    "description": "NextCursor is passed as cursor to get the next page; omitted on the last one.",
+                    "type": "string"
+                },
                 "placeholders": {
                     "type": "array",
                     "items": {
                         "$ref": "#/definitions/dto.PlaceholderResp"
                     }
+                },
+                "total": {
+                    "description": "Total is the number of placeholders matching the filters, with total=true.",
+                    "type": "integer"
                 }
             }
         },
         "dto.PlaceholderResp": {
             "type": "object",
             "properties": {
+                "created_at": {
+                    "type": "string"
+                },
                 "id": {
                     "type": "string"
                 },
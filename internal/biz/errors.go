@@ -7,4 +7,6 @@ var (
 	ErrResourceNotFound     = errors.New("placeholder resource not found")
 	ErrResourceExists       = errors.New("placeholder resource already exists")
 	ErrResourceInvalid      = errors.New("invalid placeholder resource")
+	ErrServiceDraining      = errors.New("service is draining")
+	ErrServiceStarting      = errors.New("service is starting")
 )
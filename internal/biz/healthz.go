@@ -2,10 +2,13 @@ package biz
 
 import (
 	"application/app"
+	"application/internal/entity"
 	"context"
 	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
+	"strings"
 	"sync"
 
 	"go.opentelemetry.io/otel"
@@ -29,21 +32,71 @@ func NewHealthz(logger *slog.Logger, controller app.Controller) *healthz {
 	}
 }
 
+// Readiness fails with ErrServiceDraining once the application started shutting down.
 func (uc *healthz) Readiness(ctx context.Context) error {
-	return uc.checkers(ctx, uc.controller.GetHealthzReadiness())
+	if uc.controller.IsDraining() {
+		return ErrServiceDraining
+	}
+
+	return reportError(uc.checkers(ctx, uc.controller.GetHealthzReadiness()))
 }
 
 func (uc *healthz) Liveness(ctx context.Context) error {
+	return reportError(uc.checkers(ctx, uc.controller.GetHealthzLiveness()))
+}
+
+// Startup fails with ErrServiceStarting until every component started.
+func (uc *healthz) Startup(ctx context.Context) error {
+	if !uc.controller.IsStarted() {
+		return ErrServiceStarting
+	}
+
+	return reportError(uc.checkers(ctx, uc.controller.GetHealthzStartup()))
+}
+
+// ReadinessReport fails without running the checks once the application started shutting down.
+func (uc *healthz) ReadinessReport(ctx context.Context) entity.HealthReport {
+	if uc.controller.IsDraining() {
+		return entity.HealthReport{Status: entity.HealthFail, Output: ErrServiceDraining.Error()}
+	}
+
+	return uc.checkers(ctx, uc.controller.GetHealthzReadiness())
+}
+
+func (uc *healthz) LivenessReport(ctx context.Context) entity.HealthReport {
 	return uc.checkers(ctx, uc.controller.GetHealthzLiveness())
 }
 
-func (uc *healthz) checkers(
-	ctx context.Context, checkFunc map[string]func(ctx context.Context) error,
-) error {
+// StartupReport fails without running the checks until every component started.
+func (uc *healthz) StartupReport(ctx context.Context) entity.HealthReport {
+	if !uc.controller.IsStarted() {
+		return entity.HealthReport{Status: entity.HealthFail, Output: ErrServiceStarting.Error()}
+	}
+
+	return uc.checkers(ctx, uc.controller.GetHealthzStartup())
+}
+
+// reportError joins the errors of the failed critical checks. Checks below
+// their failure threshold do not fail the probe.
+func reportError(report entity.HealthReport) error {
+	var err error
+
+	for _, check := range report.Checks {
+		if check.Status == entity.HealthFail && check.Severity == entity.HealthCritical {
+			err = errors.Join(err, fmt.Errorf("service %s failed.: %w", check.Name, check.Error))
+		}
+	}
+
+	return err
+}
+
+func (uc *healthz) checkers(ctx context.Context, checks []app.HealthzCheck) entity.HealthReport {
 	logger := uc.logger.With("method", "checkers")
 
-	if len(checkFunc) == 0 {
-		return nil
+	report := entity.HealthReport{Status: entity.HealthPass}
+
+	if len(checks) == 0 {
+		return report
 	}
 
 	ctx, span := uc.tracer.Start(ctx, "checkers",
@@ -52,50 +105,95 @@ func (uc *healthz) checkers(
 	defer span.End()
 
 	wg := sync.WaitGroup{}
+	results := make([]entity.HealthCheck, len(checks))
 
-	errCh := make(chan struct {
-		name string
-		err  error
-	}, len(checkFunc))
-
-	for name, check := range checkFunc {
+	for i, check := range checks {
 		wg.Add(1)
 
-		go func(name string, check func(ctx context.Context) error) {
+		go func() {
 			defer wg.Done()
 
-			ctx, span := uc.tracer.Start(ctx, fmt.Sprintf("Readiness-%s", name),
-				trace.WithAttributes(attribute.String("name", name)),
+			ctx, span := uc.tracer.Start(ctx, fmt.Sprintf("Readiness-%s", check.Name()),
+				trace.WithAttributes(attribute.String("name", check.Name())),
 			)
 			defer span.End()
 
-			if err := check(ctx); err != nil {
-				errCh <- struct {
-					name string
-					err  error
-				}{name: name, err: err}
+			result := check.Check(ctx)
+
+			results[i] = entity.HealthCheck{
+				Name:     check.Name(),
+				Status:   entity.HealthPass,
+				Severity: entity.HealthSeverity(check.Severity()),
+				Latency:  result.Latency,
+				Error:    result.Err,
+				Failures: result.Failures,
+				Time:     result.Time,
+			}
+
+			switch {
+			case result.Failing:
+				results[i].Status = entity.HealthFail
 
-				logger.ErrorContext(ctx, "check failed", "name", name, "error", err)
+				logger.ErrorContext(ctx, "check failed", "name", check.Name(), "error", result.Err)
+			case result.Err != nil:
+				results[i].Status = entity.HealthWarn
+
+				logger.WarnContext(ctx, "check failed below threshold",
+					"name", check.Name(), "failures", result.Failures, "error", result.Err)
 			}
-		}(name, check)
+		}()
 	}
 
 	wg.Wait()
-	close(errCh)
 
-	if len(errCh) > 0 {
+	for _, result := range results {
+		if result.Status == entity.HealthFail {
+			span.RecordError(result.Error, trace.WithAttributes(attribute.String("name", result.Name)))
+		}
+
+		report.Status = worse(report.Status, overallStatus(result))
+
+		if result.Status == entity.HealthFail && result.Severity == entity.HealthDegraded {
+			report.Output = string(entity.HealthDegraded)
+		}
+	}
+
+	if report.Status == entity.HealthFail {
+		report.Output = ""
+	}
+
+	slices.SortFunc(results, func(a, b entity.HealthCheck) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	report.Checks = results
+
+	if report.Status == entity.HealthFail {
 		span.AddEvent("check failed", trace.WithStackTrace(true))
 		span.SetStatus(codes.Error, "Readiness check failed")
+	}
 
-		var err error = nil
+	return report
+}
 
-		for resp := range errCh {
-			span.RecordError(resp.err, trace.WithAttributes(attribute.String("name", resp.name)))
-			err = errors.Join(fmt.Errorf("service %s failed.: %w", resp.name, resp.err), err)
-		}
+// overallStatus is the contribution of a check to the status of its probe.
+func overallStatus(check entity.HealthCheck) entity.HealthStatus {
+	switch {
+	case check.Status == entity.HealthPass || check.Severity == entity.HealthInformational:
+		return entity.HealthPass
+	case check.Status == entity.HealthFail && check.Severity == entity.HealthCritical:
+		return entity.HealthFail
+	default:
+		return entity.HealthWarn
+	}
+}
+
+var healthStatusOrder = []entity.HealthStatus{entity.HealthPass, entity.HealthWarn, entity.HealthFail}
 
-		return err
+func worse(a, b entity.HealthStatus) entity.HealthStatus {
+	if slices.Index(healthStatusOrder, b) > slices.Index(healthStatusOrder, a) {
+		return b
 	}
 
-	return nil
+	return a
 }
@@ -1,8 +1,16 @@
 package biz
 
-import "context"
+import (
+	"application/internal/entity"
+	"context"
+)
 
 type UsecaseHealthzer interface {
 	Readiness(ctx context.Context) error
 	Liveness(ctx context.Context) error
+	Startup(ctx context.Context) error
+	// The reports return the result of every check of the probe.
+	ReadinessReport(ctx context.Context) entity.HealthReport
+	LivenessReport(ctx context.Context) entity.HealthReport
+	StartupReport(ctx context.Context) entity.HealthReport
 }
@@ -0,0 +1,139 @@
+package biz
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+const (
+	DefaultListLimit = 20
+	MaxListLimit     = 100
+)
+
+// FilterOp is how a Filter matches a field.
+type FilterOp string
+
+const (
+	// FilterPrefix matches values starting with the filter value.
+	FilterPrefix FilterOp = "prefix"
+	// FilterContains matches values containing the filter value.
+	FilterContains FilterOp = "contains"
+)
+
+// Filter narrows a list to the items whose Field matches Value.
+type Filter struct {
+	Field string
+	Op    FilterOp
+	Value string
+}
+
+// ListOptions selects one page of a list sorted by a single field. Items
+// with the same value of the field are sorted by ID, so every item shows up
+// once while paging even when items are inserted meanwhile.
+type ListOptions struct {
+	// Limit is the page size; 0 is DefaultListLimit.
+	Limit int
+	// Cursor is the NextCursor of the previous page, "" for the first one.
+	Cursor string
+	// Sort is the field to sort by; "" is the default of the list.
+	Sort string
+	Desc bool
+	// Filters must all match.
+	Filters []Filter
+	// WithTotal counts the items matching Filters on every page.
+	WithTotal bool
+}
+
+// Validate fills in the defaults of opts and checks it against the fields a
+// list can be sorted and filtered by. Errors wrap ErrResourceInvalid.
+func (o *ListOptions) Validate(defaultSort string, sortable, filterable []string) error {
+	if o.Limit == 0 {
+		o.Limit = DefaultListLimit
+	}
+
+	if o.Sort == "" {
+		o.Sort = defaultSort
+	}
+
+	var errs []error
+
+	if o.Limit < 0 || o.Limit > MaxListLimit {
+		errs = append(errs, fmt.Errorf("limit %d is not between 1 and %d", o.Limit, MaxListLimit))
+	}
+
+	if !slices.Contains(sortable, o.Sort) {
+		errs = append(errs, fmt.Errorf("cannot sort by %q", o.Sort))
+	}
+
+	for _, f := range o.Filters {
+		if !slices.Contains(filterable, f.Field) {
+			errs = append(errs, fmt.Errorf("cannot filter by %q", f.Field))
+		}
+
+		if f.Op != FilterPrefix && f.Op != FilterContains {
+			errs = append(errs, fmt.Errorf("unknown filter %q", f.Op))
+		}
+	}
+
+	if _, err := o.DecodeCursor(); err != nil {
+		errs = append(errs, err)
+	}
+
+	if len(errs) > 0 {
+		return errors.Join(append([]error{ErrResourceInvalid}, errs...)...)
+	}
+
+	return nil
+}
+
+// Cursor is the position after the last item of a page: its value of the
+// sort field and its ID.
+type Cursor struct {
+	Sort  string `json:"s"`
+	Desc  bool   `json:"d,omitempty"`
+	Value string `json:"v"`
+	ID    string `json:"i"`
+}
+
+// Encode returns the opaque form of c passed in ListOptions.Cursor.
+func (c Cursor) Encode() string {
+	b, _ := json.Marshal(c) //nolint:errchkjson
+
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// DecodeCursor returns the cursor of o, nil on the first page. A cursor of
+// another sort than o fails, as it does not point into this order.
+func (o *ListOptions) DecodeCursor() (*Cursor, error) {
+	if o.Cursor == "" {
+		return nil, nil //nolint:nilnil
+	}
+
+	b, err := base64.RawURLEncoding.DecodeString(o.Cursor)
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed cursor", ErrResourceInvalid)
+	}
+
+	c := new(Cursor)
+	if err := json.Unmarshal(b, c); err != nil {
+		return nil, fmt.Errorf("%w: malformed cursor", ErrResourceInvalid)
+	}
+
+	if c.Sort != o.Sort || c.Desc != o.Desc {
+		return nil, fmt.Errorf("%w: cursor is for another sort order", ErrResourceInvalid)
+	}
+
+	return c, nil
+}
+
+// Page is one page of a list.
+type Page[T any] struct {
+	Items []T
+	// NextCursor selects the following page, "" on the last one.
+	NextCursor string
+	// Total is set when ListOptions.WithTotal was.
+	Total *int
+}
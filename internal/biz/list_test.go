@@ -0,0 +1,65 @@
+package biz_test
+
+import (
+	"application/internal/biz"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	cursor := biz.Cursor{Sort: "name", Desc: true, Value: "b", ID: "0195c1a4-7b2e-7c3d-9e8f-1a2b3c4d5e6f"}
+	opts := biz.ListOptions{Sort: "name", Desc: true, Cursor: cursor.Encode()}
+
+	decoded, err := opts.DecodeCursor()
+	require.NoError(t, err)
+	assert.Equal(t, &cursor, decoded)
+
+	opts.Cursor = ""
+	decoded, err = opts.DecodeCursor()
+	require.NoError(t, err)
+	assert.Nil(t, decoded, "the first page has no cursor")
+}
+
+func TestCursorOtherSort(t *testing.T) {
+	cursor := biz.Cursor{Sort: "name", Value: "b", ID: "1"}.Encode()
+
+	for _, opts := range []biz.ListOptions{
+		{Sort: "created_at", Cursor: cursor},
+		{Sort: "name", Desc: true, Cursor: cursor},
+		{Sort: "name", Cursor: "not a cursor"},
+	} {
+		_, err := opts.DecodeCursor()
+		require.ErrorIs(t, err, biz.ErrResourceInvalid, "%+v", opts)
+		require.ErrorIs(t, opts.Validate("name", []string{"name", "created_at"}, nil), biz.ErrResourceInvalid)
+	}
+}
+
+func TestListOptionsLimit(t *testing.T) {
+	tests := []struct {
+		limit int
+		want  int
+		valid bool
+	}{
+		{limit: 0, want: biz.DefaultListLimit, valid: true},
+		{limit: 1, want: 1, valid: true},
+		{limit: biz.MaxListLimit, want: biz.MaxListLimit, valid: true},
+		{limit: -1, valid: false},
+		{limit: biz.MaxListLimit + 1, valid: false},
+	}
+	for _, tt := range tests {
+		opts := biz.ListOptions{Limit: tt.limit}
+		err := opts.Validate("name", []string{"name"}, nil)
+
+		if !tt.valid {
+			require.ErrorIs(t, err, biz.ErrResourceInvalid, "limit %d", tt.limit)
+
+			continue
+		}
+
+		require.NoError(t, err, "limit %d", tt.limit)
+		assert.Equal(t, tt.want, opts.Limit)
+		assert.Equal(t, "name", opts.Sort, "the default sort")
+	}
+}
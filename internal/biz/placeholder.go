@@ -1,7 +1,6 @@
 package biz
 
 import (
-	"application/internal/datasource"
 	"application/internal/entity"
 	"context"
 	"log/slog"
@@ -12,6 +11,7 @@ import (
 type placeholder struct {
 	logger          *slog.Logger
 	placeholderRepo RepositoryPlaceholder
+	tx              Transactor
 }
 
 var _ UsecasePlaceholder = (*placeholder)(nil)
@@ -19,11 +19,12 @@ var _ UsecasePlaceholder = (*placeholder)(nil)
 func NewPlaceholder(
 	logger *slog.Logger,
 	placeholderRepo RepositoryPlaceholder,
-	dbDS *datasource.PostgresDB,
+	tx Transactor,
 ) *placeholder {
 	return &placeholder{
 		logger:          logger.With("layer", "Placeholder"),
 		placeholderRepo: placeholderRepo,
+		tx:              tx,
 	}
 }
 
@@ -31,12 +32,40 @@ func (uc *placeholder) Get(ctx context.Context, id uuid.UUID) (entity.Placeholde
 	return uc.placeholderRepo.Get(ctx, id)
 }
 
-func (uc *placeholder) List(ctx context.Context) ([]entity.Placeholder, error) {
-	return uc.placeholderRepo.List(ctx)
+// List returns a page of placeholders sorted by created_at, the default, or
+// name and filtered by name.
+func (uc *placeholder) List(ctx context.Context, opts ListOptions) (Page[entity.Placeholder], error) {
+	if err := opts.Validate("created_at", []string{"created_at", "name"}, []string{"name"}); err != nil {
+		return Page[entity.Placeholder]{}, err
+	}
+
+	return uc.placeholderRepo.List(ctx, opts)
 }
 
+// Create inserts the placeholder and reads it back in one transaction. The
+// read joins the transaction, so it is served by the primary and sees the
+// row even when reads are routed to lagging replicas; when it fails, the
+// insert is rolled back.
 func (uc *placeholder) Create(ctx context.Context, name string) (uuid.UUID, error) {
-	return uc.placeholderRepo.Create(ctx, name)
+	var id uuid.UUID
+
+	err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+
+		id, err = uc.placeholderRepo.Create(ctx, name)
+		if err != nil {
+			return err
+		}
+
+		_, err = uc.placeholderRepo.Get(ctx, id)
+
+		return err
+	})
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	return id, nil
 }
 
 func (uc *placeholder) Update(ctx context.Context, id uuid.UUID, name string) error {
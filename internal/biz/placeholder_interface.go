@@ -1,15 +1,22 @@
 package biz
 
 import (
+	"application/internal/datasource"
 	"application/internal/entity"
 	"context"
 
 	"github.com/google/uuid"
 )
 
+// Transactor runs fn in a transaction that repository calls made with the
+// ctx passed to fn join. Nested calls map to savepoints.
+type Transactor interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error, opts ...datasource.TxOption) error
+}
+
 type UsecasePlaceholder interface {
 	Get(ctx context.Context, id uuid.UUID) (entity.Placeholder, error)
-	List(ctx context.Context) ([]entity.Placeholder, error)
+	List(ctx context.Context, opts ListOptions) (Page[entity.Placeholder], error)
 	Create(ctx context.Context, name string) (uuid.UUID, error)
 	Update(ctx context.Context, id uuid.UUID, name string) error
 	Delete(ctx context.Context, id uuid.UUID) error
@@ -17,7 +24,7 @@ type UsecasePlaceholder interface {
 
 type RepositoryPlaceholder interface {
 	Get(ctx context.Context, id uuid.UUID) (entity.Placeholder, error)
-	List(ctx context.Context) ([]entity.Placeholder, error)
+	List(ctx context.Context, opts ListOptions) (Page[entity.Placeholder], error)
 	Create(ctx context.Context, name string) (uuid.UUID, error)
 	Update(ctx context.Context, id uuid.UUID, name string) error
 	Delete(ctx context.Context, id uuid.UUID) error
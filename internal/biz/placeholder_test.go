@@ -0,0 +1,72 @@
+package biz_test
+
+import (
+	"application/internal/biz"
+	"application/internal/datasource"
+	"application/internal/datasource/sqltest"
+	"application/internal/entity"
+	"application/internal/mocks"
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// newTxManager returns a TxManager over a recorder of the transaction statements.
+func newTxManager(t *testing.T) (*datasource.TxManager, *sqltest.Recorder) {
+	t.Helper()
+
+	rec := &sqltest.Recorder{}
+	db := sql.OpenDB(rec)
+	t.Cleanup(func() { _ = db.Close() })
+
+	txm, err := datasource.NewTxManager(slog.New(slog.DiscardHandler), datasource.NewPostgresDBFromDB(db))
+	require.NoError(t, err)
+
+	return txm, rec
+}
+
+func TestPlaceholderCreate(t *testing.T) {
+	ctx := context.Background()
+	txm, rec := newTxManager(t)
+	repo := mocks.NewMockRepositoryPlaceholder(t)
+	id := uuid.New()
+
+	repo.EXPECT().Create(mock.Anything, "a").RunAndReturn(func(ctx context.Context, _ string) (uuid.UUID, error) {
+		assert.True(t, datasource.InTx(ctx), "the insert runs in the transaction")
+
+		return id, nil
+	}).Once()
+	repo.EXPECT().Get(mock.Anything, id).RunAndReturn(func(ctx context.Context, id uuid.UUID) (entity.Placeholder, error) {
+		assert.True(t, datasource.InTx(ctx), "the read joins the transaction of the insert")
+
+		return entity.Placeholder{ID: id, Name: "a"}, nil
+	}).Once()
+
+	got, err := biz.NewPlaceholder(slog.New(slog.DiscardHandler), repo, txm).Create(ctx, "a")
+	require.NoError(t, err)
+	assert.Equal(t, id, got)
+	assert.Equal(t, []string{"BEGIN", "COMMIT"}, rec.Queries())
+}
+
+func TestPlaceholderCreateRollsBack(t *testing.T) {
+	ctx := context.Background()
+	txm, rec := newTxManager(t)
+	repo := mocks.NewMockRepositoryPlaceholder(t)
+	id := uuid.New()
+	errRead := errors.New("read failed")
+
+	repo.EXPECT().Create(mock.Anything, "a").Return(id, nil).Once()
+	repo.EXPECT().Get(mock.Anything, id).Return(entity.Placeholder{}, errRead).Once()
+
+	got, err := biz.NewPlaceholder(slog.New(slog.DiscardHandler), repo, txm).Create(ctx, "a")
+	require.ErrorIs(t, err, errRead)
+	assert.Equal(t, uuid.Nil, got)
+	assert.Equal(t, []string{"BEGIN", "ROLLBACK"}, rec.Queries(), "the insert is rolled back")
+}
@@ -0,0 +1,49 @@
+package datasource
+
+import (
+	"application/app"
+	"context"
+	"database/sql"
+	"io"
+	"log/slog"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// AddReplica adds db as a replica of p, healthy or ejected.
+func AddReplica(p *PostgresDB, db *sql.DB, healthy bool) {
+	r := &replica{name: "replica", db: db}
+	r.healthy.Store(healthy)
+
+	p.replicas = append(p.replicas, r)
+}
+
+// ReplicaConnString returns the connection string of r, a replica of primary.
+func ReplicaConnString(r PostgresReplicaConfig, primary *PostgresConfig) string {
+	return r.config(primary).ConnString()
+}
+
+// SetReplicaPolicy overrides datasource.postgres.replica_policy.
+func SetReplicaPolicy(p *PostgresDB, policy string) {
+	p.config.ReplicaPolicy = policy
+}
+
+// PgxTracer is the tracer PgxPool installs on its connections.
+type PgxTracer = pgxTracer
+
+func NewPgxTracer(tracer trace.Tracer) *PgxTracer {
+	return &pgxTracer{tracer: tracer}
+}
+
+// ListenConn is the connection PostgresSubscriber listens on.
+type ListenConn = listenConn
+
+// NewPostgresSubscriberWithDial returns a subscriber connecting with dial,
+// started by Start and stopped by Shutdown.
+func NewPostgresSubscriberWithDial(retry app.RetryConfig, dial func(ctx context.Context) (ListenConn, error)) *PostgresSubscriber {
+	return newPostgresSubscriber(slog.New(slog.NewTextHandler(io.Discard, nil)), retry, dial)
+}
+
+func (s *PostgresSubscriber) Start(ctx context.Context) error    { return s.start(ctx) }
+func (s *PostgresSubscriber) Shutdown(ctx context.Context) error { return s.shutdown(ctx) }
+func (s *PostgresSubscriber) Healthz(ctx context.Context) error  { return s.healthz(ctx) }
@@ -0,0 +1,334 @@
+package datasource
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"regexp"
+	"slices"
+	"strconv"
+)
+
+var (
+	ErrMigrationDirty    = errors.New("database is dirty, fix it and run migrate force")
+	ErrMigrationNotFound = errors.New("migration not found")
+	ErrMigrationNoDown   = errors.New("migration has no down file")
+	ErrMigrationFile     = errors.New("invalid migration file")
+)
+
+// migrationLockID is the key of the advisory lock held while migrating, so
+// replicas starting together apply the migrations once.
+const migrationLockID int64 = 0x6170705f6d696772 // "app_migr"
+
+// Migration is one version of the schema.
+type Migration struct {
+	Version uint64
+	Name    string
+	up      string
+	down    string
+}
+
+// MigrationStatus is the state of the schema_migrations table.
+type MigrationStatus struct {
+	// Version is the last applied migration, 0 when none is.
+	Version uint64
+	// Dirty is set when a migration failed halfway; see Migrator.Force.
+	Dirty      bool
+	Migrations []Migration
+}
+
+// Migrator applies golang-migrate style migrations, recording the version in
+// the same schema_migrations table as golang-migrate does, so either tool can
+// take over from the other.
+type Migrator struct {
+	db         *sql.DB
+	logger     *slog.Logger
+	migrations []Migration
+}
+
+var migrationFile = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+
+// NewMigrator reads the migrations from the root of fsys. Files not named
+// like migrations are ignored.
+func NewMigrator(db *sql.DB, fsys fs.FS, logger *slog.Logger) (*Migrator, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[uint64]*Migration{}
+
+	for _, e := range entries {
+		m := migrationFile.FindStringSubmatch(e.Name())
+		if m == nil || e.IsDir() {
+			continue
+		}
+
+		version, err := strconv.ParseUint(m[1], 10, 64)
+		if err != nil || version == 0 {
+			return nil, fmt.Errorf("%w: %s", ErrMigrationFile, e.Name())
+		}
+
+		b, err := fs.ReadFile(fsys, e.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: m[2]}
+			byVersion[version] = migration
+		}
+
+		if migration.Name != m[2] {
+			return nil, fmt.Errorf("%w: %s and %s share version %d", ErrMigrationFile, migration.Name, m[2], version)
+		}
+
+		if m[3] == "up" {
+			migration.up = string(b)
+		} else {
+			migration.down = string(b)
+		}
+	}
+
+	migrator := &Migrator{db: db, logger: logger.With("component", "migrate")}
+
+	for _, m := range byVersion {
+		if m.up == "" {
+			return nil, fmt.Errorf("%w: %d_%s has no up file", ErrMigrationFile, m.Version, m.Name)
+		}
+
+		migrator.migrations = append(migrator.migrations, *m)
+	}
+
+	slices.SortFunc(migrator.migrations, func(a, b Migration) int { return cmp.Compare(a.Version, b.Version) })
+
+	return migrator, nil
+}
+
+// Up applies every pending migration.
+func (m *Migrator) Up(ctx context.Context) error {
+	if len(m.migrations) == 0 {
+		return nil
+	}
+
+	return m.Goto(ctx, m.migrations[len(m.migrations)-1].Version)
+}
+
+// Down reverts the last applied migration. An applied version without a
+// migration file fails with ErrMigrationNotFound.
+func (m *Migrator) Down(ctx context.Context) error {
+	return m.locked(ctx, func(conn *sql.Conn, version uint64) error {
+		if version == 0 {
+			m.logger.InfoContext(ctx, "No migrations to revert")
+
+			return nil
+		}
+
+		i := m.index(version)
+		if i < 0 {
+			return fmt.Errorf("%w: applied version %d", ErrMigrationNotFound, version)
+		}
+
+		target := uint64(0)
+		if i > 0 {
+			target = m.migrations[i-1].Version
+		}
+
+		return m.migrate(ctx, conn, version, target)
+	})
+}
+
+// Goto migrates up or down to version; 0 reverts every migration. Like Down,
+// an applied version without a migration file fails with ErrMigrationNotFound.
+func (m *Migrator) Goto(ctx context.Context, version uint64) error {
+	if version != 0 && m.index(version) < 0 {
+		return fmt.Errorf("%w: %d", ErrMigrationNotFound, version)
+	}
+
+	return m.locked(ctx, func(conn *sql.Conn, current uint64) error {
+		if current != 0 && m.index(current) < 0 {
+			return fmt.Errorf("%w: applied version %d", ErrMigrationNotFound, current)
+		}
+
+		return m.migrate(ctx, conn, current, version)
+	})
+}
+
+// Force records version as applied and clean without running anything, to
+// recover from a failed migration once the schema was fixed by hand.
+func (m *Migrator) Force(ctx context.Context, version uint64) error {
+	if version != 0 && m.index(version) < 0 {
+		return fmt.Errorf("%w: %d", ErrMigrationNotFound, version)
+	}
+
+	return m.withLock(ctx, func(conn *sql.Conn) error {
+		return setVersion(ctx, conn, version, false)
+	})
+}
+
+// Status returns the applied version and the known migrations.
+func (m *Migrator) Status(ctx context.Context) (MigrationStatus, error) {
+	status := MigrationStatus{Migrations: slices.Clone(m.migrations)}
+
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		var err error
+
+		status.Version, status.Dirty, err = version(ctx, conn)
+
+		return err
+	})
+
+	return status, err
+}
+
+// locked runs fn with the current version under the advisory lock, refusing a dirty database.
+func (m *Migrator) locked(ctx context.Context, fn func(conn *sql.Conn, version uint64) error) error {
+	return m.withLock(ctx, func(conn *sql.Conn) error {
+		current, dirty, err := version(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		if dirty {
+			return fmt.Errorf("%w: version %d", ErrMigrationDirty, current)
+		}
+
+		return fn(conn, current)
+	})
+}
+
+// withLock holds the session advisory lock on a dedicated connection, which
+// blocks until other replicas finished migrating.
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn) error) (err error) {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, migrationLockID); err != nil {
+		return fmt.Errorf("acquire migration lock: %w", err)
+	}
+
+	defer func() {
+		// The lock is released with the session when unlocking fails.
+		if _, unlockErr := conn.ExecContext(context.WithoutCancel(ctx),
+			`SELECT pg_advisory_unlock($1)`, migrationLockID); unlockErr != nil {
+			err = errors.Join(err, unlockErr)
+		}
+	}()
+
+	if _, err := conn.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version bigint NOT NULL PRIMARY KEY,
+		dirty boolean NOT NULL
+	)`); err != nil {
+		return err
+	}
+
+	return fn(conn)
+}
+
+// migrate applies the up migrations after current through target, or the
+// down migrations from current back to above target.
+func (m *Migrator) migrate(ctx context.Context, conn *sql.Conn, current, target uint64) error {
+	if current == target {
+		m.logger.InfoContext(ctx, "No migrations to apply", "version", current)
+
+		return nil
+	}
+
+	for _, migration := range m.migrations {
+		if migration.Version > current && migration.Version <= target {
+			if err := m.apply(ctx, conn, migration, "up", migration.up, migration.Version); err != nil {
+				return err
+			}
+		}
+	}
+
+	for i, migration := range slices.Backward(m.migrations) {
+		if migration.Version <= current && migration.Version > target {
+			if migration.down == "" {
+				return fmt.Errorf("%w: %d_%s", ErrMigrationNoDown, migration.Version, migration.Name)
+			}
+
+			previous := uint64(0)
+			if i > 0 {
+				previous = m.migrations[i-1].Version
+			}
+
+			if err := m.apply(ctx, conn, migration, "down", migration.down, previous); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// apply runs one migration file like golang-migrate: the version is marked
+// dirty first and clean once the file succeeded, as files may hold statements
+// that cannot run in a transaction.
+func (m *Migrator) apply(ctx context.Context, conn *sql.Conn, migration Migration, direction, query string, after uint64) error {
+	logger := m.logger.With("version", migration.Version, "name", migration.Name, "direction", direction)
+	logger.InfoContext(ctx, "Applying migration")
+
+	if err := setVersion(ctx, conn, migration.Version, true); err != nil {
+		return err
+	}
+
+	if _, err := conn.ExecContext(ctx, query); err != nil {
+		logger.ErrorContext(ctx, "Migration failed, database is dirty", "error", err)
+
+		return fmt.Errorf("migration %d_%s %s: %w", migration.Version, migration.Name, direction, err)
+	}
+
+	return setVersion(ctx, conn, after, false)
+}
+
+func (m *Migrator) index(version uint64) int {
+	return slices.IndexFunc(m.migrations, func(migration Migration) bool { return migration.Version == version })
+}
+
+func version(ctx context.Context, conn *sql.Conn) (uint64, bool, error) {
+	var (
+		version int64
+		dirty   bool
+	)
+
+	err := conn.QueryRowContext(ctx, `SELECT version, dirty FROM schema_migrations LIMIT 1`).Scan(&version, &dirty)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, false, nil
+	}
+
+	if err != nil {
+		return 0, false, err
+	}
+
+	return uint64(version), dirty, nil //nolint:gosec
+}
+
+// setVersion replaces the single row of schema_migrations; version 0 leaves it empty.
+func setVersion(ctx context.Context, conn *sql.Conn, version uint64, dirty bool) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM schema_migrations`); err != nil {
+		return errors.Join(err, tx.Rollback())
+	}
+
+	if version > 0 {
+		if _, err := tx.ExecContext(ctx, `INSERT INTO schema_migrations (version, dirty) VALUES ($1, $2)`,
+			int64(version), dirty); err != nil { //nolint:gosec
+			return errors.Join(err, tx.Rollback())
+		}
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,155 @@
+package datasource_test
+
+import (
+	"application/internal/datasource"
+	"application/internal/datasource/sqltest"
+	"application/migrations"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMigrator(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	_, err := datasource.NewMigrator(nil, migrations.FS, logger)
+	require.NoError(t, err)
+
+	_, err = datasource.NewMigrator(nil, fstest.MapFS{
+		"000001_init.up.sql":   {Data: []byte("SELECT 1")},
+		"000001_other.up.sql":  {Data: []byte("SELECT 1")},
+		"000002_next.down.sql": {Data: []byte("SELECT 1")},
+	}, logger)
+	require.ErrorIs(t, err, datasource.ErrMigrationFile)
+
+	_, err = datasource.NewMigrator(nil, fstest.MapFS{
+		"000002_next.down.sql": {Data: []byte("SELECT 1")},
+		"README.md":            {Data: []byte("ignored")},
+	}, logger)
+	require.ErrorIs(t, err, datasource.ErrMigrationFile)
+}
+
+// schemaMigrations emulates the schema_migrations table on a recorder and
+// keeps the migration files run, failing the one equal to fail.
+type schemaMigrations struct {
+	version int64
+	dirty   bool
+	fail    string
+	ran     []string
+}
+
+func (s *schemaMigrations) exec(query string, args []any) error {
+	switch {
+	case strings.HasPrefix(query, "DELETE FROM schema_migrations"):
+		s.version, s.dirty = 0, false
+	case strings.HasPrefix(query, "INSERT INTO schema_migrations"):
+		s.version, s.dirty = args[0].(int64), args[1].(bool)
+	case strings.HasPrefix(query, "migration "):
+		s.ran = append(s.ran, query)
+
+		if query == s.fail {
+			return errors.New("syntax error")
+		}
+	}
+
+	return nil
+}
+
+func (s *schemaMigrations) query(string, []any) (driver.Rows, error) {
+	columns := []string{"version", "dirty"}
+	if s.version == 0 {
+		return sqltest.Rows(columns), nil
+	}
+
+	return sqltest.Rows(columns, []driver.Value{s.version, s.dirty}), nil
+}
+
+// newTestMigrator returns a migrator of three migrations over state.
+func newTestMigrator(t *testing.T, state *schemaMigrations) *datasource.Migrator {
+	t.Helper()
+
+	files := fstest.MapFS{}
+	for _, name := range []string{"000001_a", "000002_b", "000003_c"} {
+		files[name+".up.sql"] = &fstest.MapFile{Data: []byte("migration " + name + " up")}
+		files[name+".down.sql"] = &fstest.MapFile{Data: []byte("migration " + name + " down")}
+	}
+
+	db := sql.OpenDB(&sqltest.Recorder{Exec: state.exec, Query: state.query})
+	t.Cleanup(func() { _ = db.Close() })
+
+	m, err := datasource.NewMigrator(db, files, slog.New(slog.DiscardHandler))
+	require.NoError(t, err)
+
+	return m
+}
+
+func TestMigratorOrder(t *testing.T) {
+	ctx := context.Background()
+	state := &schemaMigrations{}
+	m := newTestMigrator(t, state)
+
+	require.NoError(t, m.Goto(ctx, 2))
+	require.NoError(t, m.Up(ctx))
+	assert.Equal(t, []string{"migration 000001_a up", "migration 000002_b up", "migration 000003_c up"}, state.ran)
+	assert.Equal(t, int64(3), state.version)
+
+	state.ran = nil
+	require.NoError(t, m.Down(ctx))
+	assert.Equal(t, []string{"migration 000003_c down"}, state.ran)
+	assert.Equal(t, int64(2), state.version)
+
+	state.ran = nil
+	require.NoError(t, m.Goto(ctx, 0))
+	assert.Equal(t, []string{"migration 000002_b down", "migration 000001_a down"}, state.ran)
+	assert.Equal(t, int64(0), state.version)
+
+	state.ran = nil
+	require.NoError(t, m.Down(ctx), "nothing to revert")
+	assert.Empty(t, state.ran)
+
+	state.version = 7
+	require.ErrorIs(t, m.Down(ctx), datasource.ErrMigrationNotFound, "the applied version has no file")
+	require.ErrorIs(t, m.Goto(ctx, 4), datasource.ErrMigrationNotFound)
+
+	state.version = 5
+	require.ErrorIs(t, m.Goto(ctx, 1), datasource.ErrMigrationNotFound, "the applied version has no file")
+	require.ErrorIs(t, m.Up(ctx), datasource.ErrMigrationNotFound)
+	assert.Empty(t, state.ran, "nothing is reverted past an unknown version")
+	assert.Equal(t, int64(5), state.version)
+}
+
+func TestMigratorDirty(t *testing.T) {
+	ctx := context.Background()
+	state := &schemaMigrations{fail: "migration 000002_b up"}
+	m := newTestMigrator(t, state)
+
+	require.Error(t, m.Up(ctx))
+	assert.Equal(t, []string{"migration 000001_a up", "migration 000002_b up"}, state.ran)
+
+	status, err := m.Status(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), status.Version)
+	assert.True(t, status.Dirty, "the failed migration leaves the database dirty")
+
+	state.ran, state.fail = nil, ""
+	require.ErrorIs(t, m.Up(ctx), datasource.ErrMigrationDirty)
+	require.ErrorIs(t, m.Down(ctx), datasource.ErrMigrationDirty)
+	assert.Empty(t, state.ran)
+
+	require.NoError(t, m.Force(ctx, 1))
+	assert.False(t, state.dirty)
+
+	require.NoError(t, m.Up(ctx))
+	assert.Equal(t, []string{"migration 000002_b up", "migration 000003_c up"}, state.ran)
+	assert.Equal(t, int64(3), state.version)
+	assert.False(t, state.dirty)
+}
@@ -3,6 +3,7 @@ package datasource
 import (
 	"application/app"
 	"context"
+	"errors"
 	"log/slog"
 	"strings"
 
@@ -19,13 +20,29 @@ type Nats struct {
 }
 
 type NatsConfig struct {
-	DSN           string `json:"dsn"`
-	InitJetstream bool   `json:"initJetstream"`
-	StreamName    string `json:"streamName"`
-	Subjects      string `json:"subject"`
+	DSN           app.Secret `koanf:"dsn"            doc:"nats:// URL, or a secret reference such as file:///run/secrets/nats_dsn"`
+	InitJetstream bool       `koanf:"initJetstream" doc:"create or update the JetStream stream on start"`
+	StreamName    string     `koanf:"streamName"    doc:"required with initJetstream"`
+	Subjects      string     `koanf:"subject"       doc:"comma separated, required with initJetstream"`
 }
 
-func NewNats(ctx context.Context, logger *slog.Logger, config app.KConfig) (*Nats, error) {
+func init() {
+	app.RegisterConfig[NatsConfig]("datasource.nats")
+}
+
+// Validate implements app.ConfigValidator.
+func (c *NatsConfig) Validate() error {
+	if !c.InitJetstream {
+		return nil
+	}
+
+	return errors.Join(
+		app.Required("streamName", c.StreamName),
+		app.Required("subject", c.Subjects),
+	)
+}
+
+func NewNats(ctx context.Context, logger *slog.Logger, config *app.KConfig) (*Nats, error) {
 	cfg := new(NatsConfig)
 	if err := config.Unmarshal("datasource.nats", cfg); err != nil {
 		logger.Error("Failed to unmarshal NATS config", "error", err)
@@ -33,7 +50,7 @@ func NewNats(ctx context.Context, logger *slog.Logger, config app.KConfig) (*Nat
 		return nil, err
 	}
 
-	nc, err := nats.Connect(cfg.DSN)
+	nc, err := nats.Connect(cfg.DSN.Value())
 	if err != nil {
 		logger.Error("Failed to connect to NATS", "error", err)
 
@@ -0,0 +1,218 @@
+package datasource
+
+import (
+	"application/app"
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	otelmetricapi "go.opentelemetry.io/otel/metric"
+	otelsemconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// PgxPool is a native pgx pool on the datasource.postgres config, for
+// features database/sql hides: CopyFrom, batches, LISTEN/NOTIFY and pgx
+// types. Unlike PostgresDB it neither migrates nor routes to replicas.
+type PgxPool struct {
+	*pgxpool.Pool
+
+	logger  *slog.Logger
+	metrics otelmetricapi.Registration
+}
+
+func NewPgxPool(
+	ctx context.Context,
+	logger *slog.Logger,
+	controller app.Controller,
+	config *app.KConfig,
+) (*PgxPool, error) {
+	cfg, err := NewPostgresConfig(config)
+	if err != nil {
+		logger.Error("Failed to unmarshal Postgres config", "error", err)
+
+		return nil, err
+	}
+
+	if !cfg.Enabled {
+		return nil, ErrPostgresDisabled
+	}
+
+	poolConfig, err := pgxpool.ParseConfig(cfg.ConnString())
+	if err != nil {
+		return nil, err
+	}
+
+	// database/sql's 0 is unlimited; pgxpool keeps its default then.
+	if cfg.Pool.MaxOpenConns > 0 {
+		poolConfig.MaxConns = int32(min(cfg.Pool.MaxOpenConns, 1<<31-1)) //nolint:gosec
+	}
+
+	poolConfig.MinConns = int32(min(cfg.Pool.MinConns, 1<<31-1)) //nolint:gosec
+	poolConfig.MaxConnLifetime = cfg.Pool.ConnMaxLifetime
+	poolConfig.MaxConnIdleTime = cfg.Pool.ConnMaxIdleTime
+
+	attrs := []attribute.KeyValue{
+		otelsemconv.DBSystemNamePostgreSQL,
+		otelsemconv.DBNamespace(poolConfig.ConnConfig.Database),
+		otelsemconv.ServerAddress(poolConfig.ConnConfig.Host),
+		otelsemconv.ServerPort(int(poolConfig.ConnConfig.Port)),
+	}
+	poolConfig.ConnConfig.Tracer = &pgxTracer{tracer: otel.Tracer("application/internal/datasource/pgxpool"), attrs: attrs}
+
+	pool, err := pgxpool.NewWithConfig(ctx, poolConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &PgxPool{
+		Pool:   pool,
+		logger: logger.With("layer", "PgxPool"),
+	}
+
+	healthzOpts, err := cfg.connect(ctx, p.logger, pool.Ping)
+	if err != nil {
+		pool.Close()
+
+		return nil, err
+	}
+
+	p.metrics, err = registerPoolMetrics(pool, attrs)
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	controller.RegisterHealthz("pgxpool", pool.Ping, healthzOpts...)
+	controller.RegisterShutdown("pgxpool", p.shutdown, app.WithDependsOn("otlp"))
+
+	return p, nil
+}
+
+// shutdown implements app.Shutdowner.
+func (p *PgxPool) shutdown(_ context.Context) error {
+	p.logger.Info("shutting down PgxPool")
+
+	var err error
+	if p.metrics != nil {
+		err = p.metrics.Unregister()
+	}
+
+	p.Close()
+
+	return err
+}
+
+// registerPoolMetrics observes pool.Stat on every collection.
+func registerPoolMetrics(pool *pgxpool.Pool, attrs []attribute.KeyValue) (otelmetricapi.Registration, error) {
+	meter := otel.Meter("application/internal/datasource/pgxpool")
+
+	usage, usageErr := meter.Int64ObservableUpDownCounter("db.client.connection.count",
+		otelmetricapi.WithDescription("Connections by state, idle or used"), otelmetricapi.WithUnit("{connection}"))
+	maxConns, maxErr := meter.Int64ObservableUpDownCounter("db.client.connection.max",
+		otelmetricapi.WithDescription("Maximum number of connections"), otelmetricapi.WithUnit("{connection}"))
+	acquires, acquiresErr := meter.Int64ObservableCounter("db.client.connection.acquires",
+		otelmetricapi.WithDescription("Connection acquisitions by result, ok, empty (had to wait) or canceled"))
+	acquireTime, acquireTimeErr := meter.Float64ObservableCounter("db.client.connection.acquire_time",
+		otelmetricapi.WithDescription("Total time spent acquiring connections"), otelmetricapi.WithUnit("s"))
+
+	if err := errors.Join(usageErr, maxErr, acquiresErr, acquireTimeErr); err != nil {
+		return nil, err
+	}
+
+	common := otelmetricapi.WithAttributes(attrs...)
+	withState := func(state string) otelmetricapi.MeasurementOption {
+		return otelmetricapi.WithAttributes(append(attrs[:len(attrs):len(attrs)], attribute.String("state", state))...)
+	}
+	withResult := func(result string) otelmetricapi.MeasurementOption {
+		return otelmetricapi.WithAttributes(append(attrs[:len(attrs):len(attrs)], attribute.String("result", result))...)
+	}
+
+	return meter.RegisterCallback(func(_ context.Context, o otelmetricapi.Observer) error {
+		stat := pool.Stat()
+
+		o.ObserveInt64(usage, int64(stat.IdleConns()), withState("idle"))
+		o.ObserveInt64(usage, int64(stat.AcquiredConns()), withState("used"))
+		o.ObserveInt64(maxConns, int64(stat.MaxConns()), common)
+		o.ObserveInt64(acquires, stat.AcquireCount()-stat.EmptyAcquireCount(), withResult("ok"))
+		o.ObserveInt64(acquires, stat.EmptyAcquireCount(), withResult("empty"))
+		o.ObserveInt64(acquires, stat.CanceledAcquireCount(), withResult("canceled"))
+		o.ObserveFloat64(acquireTime, stat.AcquireDuration().Seconds(), common)
+
+		return nil
+	}, usage, maxConns, acquires, acquireTime)
+}
+
+// pgxTracer records a client span per query, batch, COPY and connect.
+type pgxTracer struct {
+	tracer trace.Tracer
+	attrs  []attribute.KeyValue
+}
+
+var (
+	_ pgx.QueryTracer    = (*pgxTracer)(nil)
+	_ pgx.BatchTracer    = (*pgxTracer)(nil)
+	_ pgx.CopyFromTracer = (*pgxTracer)(nil)
+	_ pgx.ConnectTracer  = (*pgxTracer)(nil)
+)
+
+func (t *pgxTracer) start(ctx context.Context, name string, attrs ...attribute.KeyValue) context.Context {
+	ctx, _ = t.tracer.Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(t.attrs...),
+		trace.WithAttributes(attrs...),
+	)
+
+	return ctx
+}
+
+func (t *pgxTracer) end(ctx context.Context, err error) {
+	span := trace.SpanFromContext(ctx)
+
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
+
+func (t *pgxTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	return t.start(ctx, "postgres.query", otelsemconv.DBQueryText(data.SQL))
+}
+
+func (t *pgxTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	t.end(ctx, data.Err)
+}
+
+func (t *pgxTracer) TraceBatchStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceBatchStartData) context.Context {
+	return t.start(ctx, "postgres.batch", otelsemconv.DBOperationBatchSize(data.Batch.Len()))
+}
+
+func (t *pgxTracer) TraceBatchQuery(ctx context.Context, _ *pgx.Conn, data pgx.TraceBatchQueryData) {
+	trace.SpanFromContext(ctx).AddEvent("query", trace.WithAttributes(otelsemconv.DBQueryText(data.SQL)))
+}
+
+func (t *pgxTracer) TraceBatchEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceBatchEndData) {
+	t.end(ctx, data.Err)
+}
+
+func (t *pgxTracer) TraceCopyFromStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceCopyFromStartData) context.Context {
+	return t.start(ctx, "postgres.copy_from", otelsemconv.DBCollectionName(data.TableName.Sanitize()))
+}
+
+func (t *pgxTracer) TraceCopyFromEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceCopyFromEndData) {
+	t.end(ctx, data.Err)
+}
+
+func (t *pgxTracer) TraceConnectStart(ctx context.Context, _ pgx.TraceConnectStartData) context.Context {
+	return t.start(ctx, "postgres.connect")
+}
+
+func (t *pgxTracer) TraceConnectEnd(ctx context.Context, data pgx.TraceConnectEndData) {
+	t.end(ctx, data.Err)
+}
@@ -0,0 +1,41 @@
+package datasource_test
+
+import (
+	"application/internal/datasource"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	otelsemconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+)
+
+func TestPgxTracer(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tracer := datasource.NewPgxTracer(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer("test"))
+	ctx := context.Background()
+
+	tracer.TraceQueryEnd(tracer.TraceQueryStart(ctx, nil, pgx.TraceQueryStartData{SQL: "SELECT 1"}), nil,
+		pgx.TraceQueryEndData{Err: pgx.ErrNoRows})
+	tracer.TraceQueryEnd(tracer.TraceQueryStart(ctx, nil, pgx.TraceQueryStartData{SQL: "SELEC"}), nil,
+		pgx.TraceQueryEndData{Err: errors.New("syntax error")})
+
+	batch := &pgx.Batch{}
+	batch.Queue("SELECT 1")
+	batch.Queue("SELECT 2")
+	tracer.TraceBatchEnd(tracer.TraceBatchStart(ctx, nil, pgx.TraceBatchStartData{Batch: batch}), nil, pgx.TraceBatchEndData{})
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 3)
+
+	assert.Equal(t, "postgres.query", spans[0].Name())
+	assert.Equal(t, codes.Unset, spans[0].Status().Code, "no rows is not an error")
+	assert.Equal(t, codes.Error, spans[1].Status().Code)
+	assert.Equal(t, "postgres.batch", spans[2].Name())
+	assert.Contains(t, spans[2].Attributes(), otelsemconv.DBOperationBatchSize(2))
+}
@@ -0,0 +1,176 @@
+package datasource
+
+import (
+	"application/app"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync/atomic"
+)
+
+const (
+	ReplicaRoundRobin = "round_robin"
+	ReplicaLeastConn  = "least_conn"
+)
+
+type PostgresReplicaConfig struct {
+	DSN  app.Secret `koanf:"dsn"  doc:"replaces every connection setting of the primary"`
+	Host string     `koanf:"host" doc:"replaces the host of the primary, in its dsn too; the other settings are the primary's"`
+	Port int        `koanf:"port" doc:"0 is the port of the primary"`
+}
+
+func (c *PostgresReplicaConfig) Validate() error {
+	if c.DSN == "" {
+		return app.Required("host", c.Host)
+	}
+
+	return nil
+}
+
+// config returns primary with the connection of the replica: its DSN, or the
+// host and port of the replica set on the primary's fields or DSN.
+func (c *PostgresReplicaConfig) config(primary *PostgresConfig) *PostgresConfig {
+	cfg := *primary
+
+	if c.DSN != "" {
+		cfg.DSN = c.DSN
+
+		return &cfg
+	}
+
+	params := map[string]string{"host": c.Host}
+	cfg.Host = c.Host
+
+	if c.Port != 0 {
+		params["port"] = strconv.Itoa(c.Port)
+		cfg.Port = c.Port
+	}
+
+	if cfg.DSN != "" {
+		cfg.DSN = app.Secret(withParams(cfg.DSN.Value(), params))
+	}
+
+	return &cfg
+}
+
+// DBTX runs queries on a pool, a connection or a transaction.
+type DBTX interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+	PrepareContext(ctx context.Context, query string) (*sql.Stmt, error)
+}
+
+type replica struct {
+	name    string
+	db      *sql.DB
+	healthy atomic.Bool
+}
+
+// healthz pings the replica, ejecting it from routing while it fails.
+func (r *replica) healthz(ctx context.Context) error {
+	err := r.db.PingContext(ctx)
+	r.healthy.Store(err == nil)
+
+	return err
+}
+
+type primaryKey struct{}
+
+// WithPrimary makes ReadOnly use the primary, so a read following a write
+// sees it despite replication lag.
+func WithPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryKey{}, true)
+}
+
+// ReadOnly returns where to run a read-only query: the transaction of ctx,
+// the primary when ctx was passed to WithPrimary or no replica is healthy,
+// and a healthy replica otherwise.
+func (p *PostgresDB) ReadOnly(ctx context.Context) DBTX {
+	if InTx(ctx) || len(p.replicas) == 0 {
+		return p.executor(ctx)
+	}
+
+	if primary, _ := ctx.Value(primaryKey{}).(bool); primary {
+		return p.DB
+	}
+
+	if r := p.pickReplica(); r != nil {
+		return r.db
+	}
+
+	return p.DB
+}
+
+func (p *PostgresDB) pickReplica() *replica {
+	var picked *replica
+
+	if p.config.ReplicaPolicy == ReplicaLeastConn {
+		inUse := 0
+
+		for _, r := range p.replicas {
+			if !r.healthy.Load() {
+				continue
+			}
+
+			if n := r.db.Stats().InUse; picked == nil || n < inUse {
+				picked, inUse = r, n
+			}
+		}
+
+		return picked
+	}
+
+	healthy := make([]*replica, 0, len(p.replicas))
+
+	for _, r := range p.replicas {
+		if r.healthy.Load() {
+			healthy = append(healthy, r)
+		}
+	}
+
+	if len(healthy) == 0 {
+		return nil
+	}
+
+	return healthy[p.nextReplica.Add(1)%uint64(len(healthy))]
+}
+
+// openReplicas connects to the replicas and registers their healthz checks.
+// An unreachable replica does not fail the start; it is used once its check passes.
+func (p *PostgresDB) openReplicas(ctx context.Context) error {
+	for i, replicaConfig := range p.config.Replicas {
+		db, err := OpenPostgres(replicaConfig.config(p.config))
+		if err != nil {
+			return errors.Join(fmt.Errorf("replica %d: %w", i, err), p.closeReplicas())
+		}
+
+		r := &replica{name: "pgx-replica-" + strconv.Itoa(i), db: db}
+
+		if err := r.healthz(ctx); err != nil {
+			p.logger.WarnContext(ctx, "Replica unreachable, reading from the primary until it is", "replica", r.name, "error", err)
+		}
+
+		p.replicas = append(p.replicas, r)
+
+		p.controller.RegisterHealthz(r.name, r.healthz,
+			app.WithInterval(HealthzInterval),
+			app.WithSeverity(app.SeverityDegraded),
+			app.WithLiveness(false),
+		)
+	}
+
+	return nil
+}
+
+func (p *PostgresDB) closeReplicas() error {
+	var errs []error
+
+	for _, r := range p.replicas {
+		errs = append(errs, r.db.Close())
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,157 @@
+// Package sqltest provides a database/sql driver recording the statements it
+// runs, for tests of code querying Postgres without a database.
+package sqltest
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"sync"
+)
+
+// Statement is one statement run on a Recorder. Transactions are recorded as
+// the statements BEGIN, BEGIN SERIALIZABLE, COMMIT and ROLLBACK.
+type Statement struct {
+	Query string
+	Args  []any
+}
+
+// Recorder is a database/sql connector recording every statement. Exec and
+// Query are called with the recorder locked, so they may keep state without
+// locking it themselves.
+type Recorder struct {
+	// Exec returns the error of a statement; nil runs every statement fine.
+	Exec func(query string, args []any) error
+	// Query returns the rows of a query; nil returns no rows.
+	Query func(query string, args []any) (driver.Rows, error)
+
+	mu  sync.Mutex
+	log []Statement
+}
+
+var (
+	_ driver.Connector = (*Recorder)(nil)
+	_ driver.Driver    = (*Recorder)(nil)
+)
+
+// Queries returns the queries run so far.
+func (r *Recorder) Queries() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	queries := make([]string, len(r.log))
+	for i, s := range r.log {
+		queries[i] = s.Query
+	}
+
+	return queries
+}
+
+// Statements returns the statements run so far.
+func (r *Recorder) Statements() []Statement {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]Statement(nil), r.log...)
+}
+
+// Reset forgets the statements run so far.
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.log = nil
+}
+
+func (r *Recorder) Connect(context.Context) (driver.Conn, error) { return conn{r}, nil }
+func (r *Recorder) Driver() driver.Driver                        { return r }
+func (r *Recorder) Open(string) (driver.Conn, error)             { return conn{r}, nil }
+
+func (r *Recorder) exec(query string, args []driver.NamedValue) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	values := r.record(query, args)
+	if r.Exec == nil {
+		return nil
+	}
+
+	return r.Exec(query, values)
+}
+
+func (r *Recorder) query(query string, args []driver.NamedValue) (driver.Rows, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	values := r.record(query, args)
+	if r.Query == nil {
+		return Rows(nil), nil
+	}
+
+	return r.Query(query, values)
+}
+
+func (r *Recorder) record(query string, args []driver.NamedValue) []any {
+	var values []any
+	for _, arg := range args {
+		values = append(values, arg.Value)
+	}
+
+	r.log = append(r.log, Statement{Query: query, Args: values})
+
+	return values
+}
+
+type conn struct{ r *Recorder }
+
+func (c conn) Prepare(string) (driver.Stmt, error) { return nil, driver.ErrSkip }
+func (c conn) Close() error                        { return nil }
+func (c conn) Begin() (driver.Tx, error)           { return c, c.r.exec("BEGIN", nil) }
+func (c conn) Commit() error                       { return c.r.exec("COMMIT", nil) }
+func (c conn) Rollback() error                     { return c.r.exec("ROLLBACK", nil) }
+
+func (c conn) BeginTx(_ context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if sql.IsolationLevel(opts.Isolation) == sql.LevelSerializable {
+		return c, c.r.exec("BEGIN SERIALIZABLE", nil)
+	}
+
+	return c.Begin()
+}
+
+func (c conn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	return driver.RowsAffected(1), c.r.exec(query, args)
+}
+
+func (c conn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	return c.r.query(query, args)
+}
+
+// Rows returns the rows of columns holding values, for Recorder.Query.
+func Rows(columns []string, values ...[]driver.Value) driver.Rows {
+	return &rows{columns: columns, values: values}
+}
+
+type rows struct {
+	columns []string
+	values  [][]driver.Value
+}
+
+func (r *rows) Columns() []string { return r.columns }
+func (r *rows) Close() error      { return nil }
+
+func (r *rows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+
+	if len(dest) != len(r.values[0]) {
+		return errors.New("sqltest: row does not match the columns")
+	}
+
+	copy(dest, r.values[0])
+	r.values = r.values[1:]
+
+	return nil
+}
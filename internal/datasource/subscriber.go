@@ -0,0 +1,420 @@
+package datasource
+
+import (
+	"application/app"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelmetricapi "go.opentelemetry.io/otel/metric"
+)
+
+var ErrSubscriberDisconnected = errors.New("postgres subscriber is not connected")
+
+// errSubscriberWoken interrupts waiting for a notification to LISTEN to a
+// channel subscribed to meanwhile.
+var errSubscriberWoken = errors.New("subscriber woken")
+
+// NotificationHandler handles a notification of a channel it subscribed to.
+// Its context outlives the shutdown of the subscriber until the shutdown
+// deadline, so a handler running then can finish.
+type NotificationHandler func(ctx context.Context, n *pgconn.Notification) error
+
+// listenConn is the part of *pgx.Conn PostgresSubscriber uses.
+type listenConn interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	WaitForNotification(ctx context.Context) (*pgconn.Notification, error)
+	Close(ctx context.Context) error
+}
+
+// PostgresSubscriber delivers LISTEN/NOTIFY notifications to the handlers
+// subscribed to their channel. It holds a dedicated connection outside of
+// the pools, reconnecting with the backoff of datasource.postgres.retry when
+// it is lost and listening to every channel again. Notifications sent while
+// disconnected are lost, as Postgres does not keep them.
+type PostgresSubscriber struct {
+	logger  *slog.Logger
+	dial    func(ctx context.Context) (listenConn, error)
+	retry   app.RetryConfig
+	metrics *subscriberMetrics
+
+	mu       sync.Mutex
+	handlers map[string][]NotificationHandler
+	cancel   context.CancelFunc
+	done     chan struct{}
+
+	wake      chan struct{}
+	connected atomic.Bool
+}
+
+func NewPostgresSubscriber(
+	logger *slog.Logger,
+	controller app.Controller,
+	config *app.KConfig,
+) (*PostgresSubscriber, error) {
+	cfg, err := NewPostgresConfig(config)
+	if err != nil {
+		logger.Error("Failed to unmarshal Postgres config", "error", err)
+
+		return nil, err
+	}
+
+	if !cfg.Enabled {
+		return nil, ErrPostgresDisabled
+	}
+
+	connConfig, err := pgx.ParseConfig(cfg.ConnString())
+	if err != nil {
+		return nil, err
+	}
+
+	s := newPostgresSubscriber(logger, cfg.Retry, func(ctx context.Context) (listenConn, error) {
+		return pgx.ConnectConfig(ctx, connConfig.Copy())
+	})
+
+	controller.RegisterStartup("pgx-subscriber", s.start, app.WithDependsOn("otlp"))
+	controller.RegisterShutdown("pgx-subscriber", s.shutdown, app.WithDependsOn("otlp"))
+	controller.RegisterHealthz("pgx-subscriber", s.healthz,
+		app.WithInterval(HealthzInterval),
+		app.WithSeverity(app.SeverityDegraded),
+		app.WithLiveness(false),
+	)
+
+	return s, nil
+}
+
+func newPostgresSubscriber(
+	logger *slog.Logger,
+	retry app.RetryConfig,
+	dial func(ctx context.Context) (listenConn, error),
+) *PostgresSubscriber {
+	return &PostgresSubscriber{
+		logger:   logger.With("layer", "PostgresSubscriber"),
+		dial:     dial,
+		retry:    retry,
+		metrics:  newSubscriberMetrics(),
+		handlers: map[string][]NotificationHandler{},
+		wake:     make(chan struct{}, 1),
+	}
+}
+
+// Subscribe calls handler for every notification of channel, in the order
+// they arrive; the handlers of a channel run one after the other. Channel
+// names are case sensitive. Subscribing after start listens to the channel
+// from then on.
+func (s *PostgresSubscriber) Subscribe(channel string, handler NotificationHandler) {
+	s.mu.Lock()
+	s.handlers[channel] = append(s.handlers[channel], handler)
+	s.mu.Unlock()
+
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// channels returns the channels subscribed to.
+func (s *PostgresSubscriber) channels() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	channels := make([]string, 0, len(s.handlers))
+	for channel := range s.handlers {
+		channels = append(channels, channel)
+	}
+
+	return channels
+}
+
+// start connects when channels were subscribed to before start, retrying
+// like the pools do, and delivers notifications in the background until shutdown.
+func (s *PostgresSubscriber) start(ctx context.Context) error {
+	var conn listenConn
+
+	if len(s.channels()) > 0 {
+		err := app.Retry(ctx, s.logger.With("action", "connect"), s.retry, func(ctx context.Context) error {
+			var err error
+
+			conn, err = s.connect(ctx)
+
+			return err
+		})
+		if err != nil {
+			if !s.retry.StartNotReady {
+				return err
+			}
+
+			s.logger.Warn("Starting without LISTEN connection, reconnecting in the background", "error", err)
+		}
+	}
+
+	// The run outlives the startup context; shutdown cancels it.
+	runCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+
+	s.mu.Lock()
+	s.cancel, s.done = cancel, make(chan struct{})
+	s.mu.Unlock()
+
+	go s.run(runCtx, conn)
+
+	return nil
+}
+
+// shutdown stops listening and waits for the running handler, if any.
+func (s *PostgresSubscriber) shutdown(ctx context.Context) error {
+	s.logger.Info("shutting down PostgresSubscriber")
+
+	s.mu.Lock()
+	cancel, done := s.cancel, s.done
+	s.mu.Unlock()
+
+	if cancel == nil {
+		return nil
+	}
+
+	cancel()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("waiting for notification handlers: %w", ctx.Err())
+	}
+}
+
+// healthz fails while channels are subscribed to but not listened to.
+func (s *PostgresSubscriber) healthz(_ context.Context) error {
+	if !s.connected.Load() && len(s.channels()) > 0 {
+		return ErrSubscriberDisconnected
+	}
+
+	return nil
+}
+
+// connect dials and listens to every channel subscribed to.
+func (s *PostgresSubscriber) connect(ctx context.Context) (listenConn, error) {
+	conn, err := s.dial(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.listen(ctx, conn); err != nil {
+		return nil, errors.Join(err, conn.Close(context.WithoutCancel(ctx)))
+	}
+
+	s.connected.Store(true)
+
+	return conn, nil
+}
+
+// run waits for notifications on conn, connecting when it is nil or lost.
+// Waiting is interrupted by Subscribe to listen to the new channel.
+func (s *PostgresSubscriber) run(ctx context.Context, conn listenConn) {
+	defer close(s.done)
+
+	interval := s.retry.InitialInterval
+	connectedBefore := conn != nil
+
+	for {
+		if conn == nil {
+			if len(s.channels()) == 0 {
+				select {
+				case <-ctx.Done():
+					return
+				case <-s.wake:
+					continue
+				}
+			}
+
+			var err error
+
+			conn, err = s.connect(ctx)
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+
+				wait := interval/2 + rand.N(interval/2+1) //nolint:gosec,mnd
+				interval = min(time.Duration(float64(interval)*s.retry.Multiplier), s.retry.MaxInterval)
+
+				s.logger.WarnContext(ctx, "Failed to reconnect, retrying", "wait", wait, "error", err)
+
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(wait):
+				}
+
+				continue
+			}
+
+			if connectedBefore {
+				s.logger.InfoContext(ctx, "Reconnected, listening again", "channels", s.channels())
+				s.metrics.reconnects.Add(ctx, 1)
+			}
+
+			connectedBefore = true
+			interval = s.retry.InitialInterval
+		}
+
+		n, err := s.wait(ctx, conn)
+
+		switch {
+		case err == nil:
+			s.dispatch(ctx, n)
+		case ctx.Err() != nil:
+			s.connected.Store(false)
+
+			if err := conn.Close(context.WithoutCancel(ctx)); err != nil {
+				s.logger.WarnContext(ctx, "Failed to close LISTEN connection", "error", err)
+			}
+
+			return
+		case errors.Is(err, errSubscriberWoken):
+			if err := s.listen(ctx, conn); err != nil {
+				s.disconnect(ctx, conn, err)
+				conn = nil
+			}
+		default:
+			s.disconnect(ctx, conn, err)
+			conn = nil
+		}
+	}
+}
+
+// wait returns the next notification, or errSubscriberWoken after Subscribe.
+func (s *PostgresSubscriber) wait(ctx context.Context, conn listenConn) (*pgconn.Notification, error) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	stop, stopped := make(chan struct{}), make(chan struct{})
+
+	go func() {
+		defer close(stopped)
+
+		select {
+		case <-s.wake:
+			cancel(errSubscriberWoken)
+		case <-stop:
+		}
+	}()
+
+	// A canceled wait leaves the connection usable: pgx only times out the read.
+	n, err := conn.WaitForNotification(ctx)
+
+	close(stop)
+	<-stopped
+
+	woken := errors.Is(context.Cause(ctx), errSubscriberWoken)
+
+	switch {
+	case n != nil && woken:
+		// Keep the wake for the next wait.
+		select {
+		case s.wake <- struct{}{}:
+		default:
+		}
+
+		return n, nil
+	case n != nil:
+		return n, nil
+	case woken:
+		return nil, errSubscriberWoken
+	}
+
+	return nil, err
+}
+
+// listen listens to every channel subscribed to; LISTEN is a no-op for a
+// channel listened to already.
+func (s *PostgresSubscriber) listen(ctx context.Context, conn listenConn) error {
+	for _, channel := range s.channels() {
+		if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{channel}.Sanitize()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *PostgresSubscriber) disconnect(ctx context.Context, conn listenConn, err error) {
+	s.connected.Store(false)
+	s.logger.WarnContext(ctx, "Lost LISTEN connection, reconnecting", "error", err)
+
+	_ = conn.Close(context.WithoutCancel(ctx))
+}
+
+// dispatch runs the handlers of the channel of n. A failing or panicking
+// handler is logged and counted and does not stop the others.
+func (s *PostgresSubscriber) dispatch(ctx context.Context, n *pgconn.Notification) {
+	s.mu.Lock()
+	handlers := s.handlers[n.Channel]
+	s.mu.Unlock()
+
+	channel := attribute.String("channel", n.Channel)
+
+	if len(handlers) == 0 {
+		s.metrics.deliveries.Add(ctx, 1, otelmetricapi.WithAttributes(channel, attribute.String("result", "unhandled")))
+
+		return
+	}
+
+	ctx = context.WithoutCancel(ctx)
+
+	for _, handler := range handlers {
+		start := time.Now()
+		err := s.handle(ctx, handler, n)
+
+		result := "ok"
+		if err != nil {
+			result = "error"
+
+			s.logger.ErrorContext(ctx, "Notification handler failed", "channel", n.Channel, "error", err)
+		}
+
+		s.metrics.deliveries.Add(ctx, 1, otelmetricapi.WithAttributes(channel, attribute.String("result", result)))
+		s.metrics.duration.Record(ctx, time.Since(start).Seconds(), otelmetricapi.WithAttributes(channel))
+	}
+}
+
+func (s *PostgresSubscriber) handle(ctx context.Context, handler NotificationHandler, n *pgconn.Notification) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("panic: %v", p) //nolint:err113
+		}
+	}()
+
+	return handler(ctx, n)
+}
+
+type subscriberMetrics struct {
+	deliveries otelmetricapi.Int64Counter
+	duration   otelmetricapi.Float64Histogram
+	reconnects otelmetricapi.Int64Counter
+}
+
+func newSubscriberMetrics() *subscriberMetrics {
+	meter := otel.Meter("application/internal/datasource/subscriber")
+
+	deliveries, deliveriesErr := meter.Int64Counter("postgres.notifications",
+		otelmetricapi.WithDescription("Notifications delivered to handlers by channel and result, ok, error or unhandled"))
+	duration, durationErr := meter.Float64Histogram("postgres.notification.duration",
+		otelmetricapi.WithDescription("Duration of notification handlers"), otelmetricapi.WithUnit("s"))
+	reconnects, reconnectsErr := meter.Int64Counter("postgres.subscriber.reconnects",
+		otelmetricapi.WithDescription("Reconnections of the LISTEN connection after it was lost"))
+
+	if err := errors.Join(deliveriesErr, durationErr, reconnectsErr); err != nil {
+		otel.Handle(err)
+	}
+
+	return &subscriberMetrics{deliveries: deliveries, duration: duration, reconnects: reconnects}
+}
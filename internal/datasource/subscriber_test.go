@@ -0,0 +1,121 @@
+package datasource_test
+
+import (
+	"application/app"
+	"application/internal/datasource"
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// listenConn is a LISTEN connection delivering what is sent on notifications
+// until lost is closed.
+type listenConn struct {
+	mu            sync.Mutex
+	listened      []string
+	closed        bool
+	notifications chan *pgconn.Notification
+	lost          chan struct{}
+}
+
+func newListenConn() *listenConn {
+	return &listenConn{notifications: make(chan *pgconn.Notification), lost: make(chan struct{})}
+}
+
+func (c *listenConn) Exec(_ context.Context, sql string, _ ...any) (pgconn.CommandTag, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.listened = append(c.listened, sql)
+
+	return pgconn.CommandTag{}, nil
+}
+
+func (c *listenConn) WaitForNotification(ctx context.Context) (*pgconn.Notification, error) {
+	select {
+	case n := <-c.notifications:
+		return n, nil
+	case <-c.lost:
+		return nil, errors.New("connection reset by peer")
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (c *listenConn) Close(context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.closed = true
+
+	return nil
+}
+
+func (c *listenConn) Listened() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return append([]string(nil), c.listened...)
+}
+
+func TestPostgresSubscriber(t *testing.T) {
+	ctx := context.Background()
+	conns := make(chan *listenConn, 2)
+	first, second := newListenConn(), newListenConn()
+	conns <- first
+	conns <- second
+
+	retry := app.RetryConfig{InitialInterval: time.Millisecond, MaxInterval: time.Millisecond, Multiplier: 1}
+	s := datasource.NewPostgresSubscriberWithDial(retry, func(context.Context) (datasource.ListenConn, error) {
+		select {
+		case c := <-conns:
+			return c, nil
+		default:
+			return nil, errors.New("connection refused")
+		}
+	})
+
+	received := make(chan string, 10)
+	s.Subscribe("Jobs", func(_ context.Context, n *pgconn.Notification) error {
+		received <- n.Channel + ":" + n.Payload
+
+		return nil
+	})
+	s.Subscribe("Jobs", func(context.Context, *pgconn.Notification) error {
+		panic("failing handler")
+	})
+
+	require.NoError(t, s.Start(ctx))
+	require.NoError(t, s.Healthz(ctx))
+	assert.Contains(t, first.Listened(), `LISTEN "Jobs"`)
+
+	first.notifications <- &pgconn.Notification{Channel: "Jobs", Payload: "1"}
+	assert.Equal(t, "Jobs:1", <-received, "a panicking handler does not stop the others")
+
+	close(first.lost)
+	second.notifications <- &pgconn.Notification{Channel: "Jobs", Payload: "2"}
+	assert.Equal(t, "Jobs:2", <-received)
+	assert.Contains(t, second.Listened(), `LISTEN "Jobs"`, "reconnecting listens again")
+
+	s.Subscribe("audit", func(_ context.Context, n *pgconn.Notification) error {
+		received <- n.Channel + ":" + n.Payload
+
+		return nil
+	})
+	require.Eventually(t, func() bool {
+		return slices.Contains(second.Listened(), `LISTEN "audit"`)
+	}, time.Second, time.Millisecond, "subscribing after start listens on the connection")
+
+	second.notifications <- &pgconn.Notification{Channel: "audit", Payload: "3"}
+	assert.Equal(t, "audit:3", <-received)
+
+	require.NoError(t, s.Shutdown(ctx))
+	assert.True(t, second.closed)
+}
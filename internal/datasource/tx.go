@@ -0,0 +1,259 @@
+package datasource
+
+import (
+	"application/app"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const (
+	// DefaultTxMaxRetries is how often a transaction is retried after a serialization failure.
+	DefaultTxMaxRetries = 3
+	// txRetryDelay is the upper bound of the random wait before a retry.
+	txRetryDelay = 50 * time.Millisecond
+)
+
+// pgSerializationFailure is the SQLSTATE of a transaction that must be retried.
+const pgSerializationFailure = "40001"
+
+var ErrTxInvalidIsolation = errors.New("invalid isolation level")
+
+type PostgresTxConfig struct {
+	Isolation  string `koanf:"isolation"   doc:"read_committed, repeatable_read or serializable"`
+	MaxRetries int    `koanf:"max_retries" doc:"retries after a serialization failure (SQLSTATE 40001)"`
+}
+
+func (c *PostgresTxConfig) SetDefaults() {
+	c.Isolation = "read_committed"
+	c.MaxRetries = DefaultTxMaxRetries
+}
+
+func (c *PostgresTxConfig) Validate() error {
+	_, err := ParseIsolation(c.Isolation)
+
+	return errors.Join(
+		app.Invalid("isolation", err),
+		app.NonNegative("max_retries", c.MaxRetries),
+	)
+}
+
+// ParseIsolation maps read_committed, repeatable_read and serializable to
+// their sql.IsolationLevel; "" is the server default.
+func ParseIsolation(level string) (sql.IsolationLevel, error) {
+	switch strings.ToLower(level) {
+	case "":
+		return sql.LevelDefault, nil
+	case "read_committed":
+		return sql.LevelReadCommitted, nil
+	case "repeatable_read":
+		return sql.LevelRepeatableRead, nil
+	case "serializable":
+		return sql.LevelSerializable, nil
+	default:
+		return sql.LevelDefault, fmt.Errorf("%w %q", ErrTxInvalidIsolation, level)
+	}
+}
+
+type txOptions struct {
+	isolation  sql.IsolationLevel
+	readOnly   bool
+	maxRetries int
+}
+
+// TxOption is a function option for WithinTx.
+type TxOption func(*txOptions)
+
+// WithIsolation overrides datasource.postgres.tx.isolation for one transaction.
+func WithIsolation(level sql.IsolationLevel) TxOption {
+	return func(o *txOptions) {
+		o.isolation = level
+	}
+}
+
+// WithReadOnly starts a read-only transaction.
+func WithReadOnly(readOnly bool) TxOption {
+	return func(o *txOptions) {
+		o.readOnly = readOnly
+	}
+}
+
+// WithMaxRetries overrides datasource.postgres.tx.max_retries for one transaction.
+func WithMaxRetries(n int) TxOption {
+	return func(o *txOptions) {
+		o.maxRetries = max(n, 0)
+	}
+}
+
+type txKey struct{}
+
+// txState is the transaction carried by the context of WithinTx.
+type txState struct {
+	tx         *sql.Tx
+	savepoints int
+}
+
+func txFromContext(ctx context.Context) *txState {
+	tx, _ := ctx.Value(txKey{}).(*txState)
+
+	return tx
+}
+
+// InTx reports whether ctx carries a transaction of WithinTx.
+func InTx(ctx context.Context) bool {
+	return txFromContext(ctx) != nil
+}
+
+// TxManager runs functions in a transaction carried by their context. The
+// query methods of PostgresDB use it, so repositories join the transaction
+// of their caller without changes.
+type TxManager struct {
+	db       *PostgresDB
+	logger   *slog.Logger
+	defaults txOptions
+}
+
+func NewTxManager(logger *slog.Logger, db *PostgresDB) (*TxManager, error) {
+	isolation, err := ParseIsolation(db.config.Tx.Isolation)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TxManager{
+		db:     db,
+		logger: logger.With("layer", "TxManager"),
+		defaults: txOptions{
+			isolation:  isolation,
+			maxRetries: db.config.Tx.MaxRetries,
+		},
+	}, nil
+}
+
+// WithinTx runs fn in a transaction, committed when fn returns nil and rolled
+// back otherwise. A call within the fn of another maps to a savepoint of the
+// outer transaction, whose options it keeps, so only its own work is rolled
+// back. A serialization failure reruns the outermost fn, so fn must not have
+// side effects outside the database.
+func (m *TxManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error, opts ...TxOption) error {
+	if state := txFromContext(ctx); state != nil {
+		return m.savepoint(ctx, state, fn)
+	}
+
+	options := m.defaults
+	for _, o := range opts {
+		o(&options)
+	}
+
+	for attempt := 0; ; attempt++ {
+		err := m.run(ctx, options, fn)
+		if err == nil || !IsSerializationFailure(err) || attempt >= options.maxRetries {
+			return err
+		}
+
+		m.logger.InfoContext(ctx, "Retrying transaction after serialization failure", "attempt", attempt+1, "error", err)
+
+		select {
+		case <-ctx.Done():
+			return errors.Join(ctx.Err(), err)
+		case <-time.After(rand.N(txRetryDelay)): //nolint:gosec
+		}
+	}
+}
+
+func (m *TxManager) run(ctx context.Context, options txOptions, fn func(ctx context.Context) error) error {
+	tx, err := m.db.DB.BeginTx(ctx, &sql.TxOptions{Isolation: options.isolation, ReadOnly: options.readOnly})
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+
+			panic(p)
+		}
+	}()
+
+	if err := fn(context.WithValue(ctx, txKey{}, &txState{tx: tx})); err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			m.logger.WarnContext(ctx, "Failed to roll back transaction", "error", rollbackErr)
+		}
+
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (m *TxManager) savepoint(ctx context.Context, state *txState, fn func(ctx context.Context) error) (err error) {
+	state.savepoints++
+	name := fmt.Sprintf("sp_%d", state.savepoints)
+
+	if _, err := state.tx.ExecContext(ctx, "SAVEPOINT "+name); err != nil {
+		return err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_, _ = state.tx.ExecContext(context.WithoutCancel(ctx), "ROLLBACK TO SAVEPOINT "+name)
+
+			panic(p)
+		}
+	}()
+
+	if err := fn(ctx); err != nil {
+		if _, rollbackErr := state.tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+name); rollbackErr != nil {
+			return errors.Join(err, rollbackErr)
+		}
+
+		return err
+	}
+
+	_, err = state.tx.ExecContext(ctx, "RELEASE SAVEPOINT "+name)
+
+	return err
+}
+
+// IsSerializationFailure reports whether err is SQLSTATE 40001, after which
+// the whole transaction can be retried.
+func IsSerializationFailure(err error) bool {
+	var pgErr *pgconn.PgError
+
+	return errors.As(err, &pgErr) && pgErr.Code == pgSerializationFailure
+}
+
+// executor returns the transaction of ctx, or the pool outside one.
+func (p *PostgresDB) executor(ctx context.Context) DBTX {
+	if state := txFromContext(ctx); state != nil {
+		return state.tx
+	}
+
+	return p.DB
+}
+
+// ExecContext runs query in the transaction of ctx, if any.
+func (p *PostgresDB) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	return p.executor(ctx).ExecContext(ctx, query, args...)
+}
+
+// QueryContext runs query in the transaction of ctx, if any.
+func (p *PostgresDB) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return p.executor(ctx).QueryContext(ctx, query, args...)
+}
+
+// QueryRowContext runs query in the transaction of ctx, if any.
+func (p *PostgresDB) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	return p.executor(ctx).QueryRowContext(ctx, query, args...)
+}
+
+// PrepareContext prepares query in the transaction of ctx, if any.
+func (p *PostgresDB) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return p.executor(ctx).PrepareContext(ctx, query)
+}
@@ -0,0 +1,76 @@
+package datasource_test
+
+import (
+	"application/internal/datasource"
+	"application/internal/datasource/sqltest"
+	"context"
+	"database/sql"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithinTx(t *testing.T) {
+	ctx := context.Background()
+	failures := 2
+	rec := &sqltest.Recorder{Exec: func(query string, _ []any) error {
+		if query == "UPDATE b" && failures > 0 {
+			failures--
+
+			return &pgconn.PgError{Code: "40001"}
+		}
+
+		return nil
+	}}
+	db := datasource.NewPostgresDBFromDB(sql.OpenDB(rec))
+
+	txm, err := datasource.NewTxManager(slog.New(slog.NewTextHandler(io.Discard, nil)), db)
+	require.NoError(t, err)
+
+	errInner := errors.New("inner")
+
+	err = txm.WithinTx(ctx, func(ctx context.Context) error {
+		assert.True(t, datasource.InTx(ctx))
+
+		if _, err := db.ExecContext(ctx, "UPDATE a"); err != nil {
+			return err
+		}
+
+		require.ErrorIs(t, txm.WithinTx(ctx, func(ctx context.Context) error {
+			_, err := db.ExecContext(ctx, "UPDATE c")
+			require.NoError(t, err)
+
+			return errInner
+		}), errInner)
+
+		_, err := db.ExecContext(ctx, "UPDATE b")
+
+		return err
+	}, datasource.WithIsolation(sql.LevelSerializable))
+	require.NoError(t, err)
+
+	attempt := []string{"BEGIN SERIALIZABLE", "UPDATE a", "SAVEPOINT sp_1", "UPDATE c", "ROLLBACK TO SAVEPOINT sp_1", "UPDATE b"}
+
+	var want []string
+	for _, end := range []string{"ROLLBACK", "ROLLBACK", "COMMIT"} {
+		want = append(append(want, attempt...), end)
+	}
+
+	assert.Equal(t, want, rec.Queries())
+
+	rec.Reset()
+
+	failures = 5
+	err = txm.WithinTx(ctx, func(ctx context.Context) error {
+		_, err := db.ExecContext(ctx, "UPDATE b")
+
+		return err
+	}, datasource.WithMaxRetries(1))
+	require.True(t, datasource.IsSerializationFailure(err))
+	assert.Equal(t, []string{"BEGIN", "UPDATE b", "ROLLBACK", "BEGIN", "UPDATE b", "ROLLBACK"}, rec.Queries())
+}
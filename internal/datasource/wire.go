@@ -5,4 +5,7 @@ import "github.com/google/wire"
 var DataProviderSet = wire.NewSet(
 	NewInmemoryDB,
 	NewPostgresDB,
+	NewTxManager,
+	NewPgxPool,
+	NewPostgresSubscriber,
 )
@@ -0,0 +1,48 @@
+package entity
+
+import "time"
+
+// HealthStatus follows the health check response format for HTTP APIs
+// (draft-inadarei-api-health-check).
+type HealthStatus string
+
+const (
+	HealthPass HealthStatus = "pass"
+	HealthWarn HealthStatus = "warn"
+	HealthFail HealthStatus = "fail"
+)
+
+// HealthSeverity is how a failing check affects the overall status.
+type HealthSeverity string
+
+const (
+	// HealthCritical failures fail the probe.
+	HealthCritical HealthSeverity = "critical"
+	// HealthDegraded failures report the probe degraded, with HealthWarn.
+	HealthDegraded HealthSeverity = "degraded"
+	// HealthInformational failures do not change the overall status.
+	HealthInformational HealthSeverity = "informational"
+)
+
+// HealthCheck is the result of one registered healthz check.
+type HealthCheck struct {
+	Name     string
+	Status   HealthStatus
+	Severity HealthSeverity
+	Latency  time.Duration
+	// Error is the failure of the latest run, nil when it passed.
+	Error error
+	// Failures counts the consecutive failed runs; a check below its failure
+	// threshold reports HealthWarn.
+	Failures int
+	Time     time.Time
+}
+
+// HealthReport is the overall result of a probe and its checks, sorted by name.
+type HealthReport struct {
+	Status HealthStatus
+	// Output explains a failure that is not caused by a check, such as
+	// draining, or is "degraded" when a degraded check fails.
+	Output string
+	Checks []HealthCheck
+}
@@ -3,9 +3,14 @@
 package mocks
 
 import (
+	biz "application/internal/biz"
 	context "context"
 
+	entity "application/internal/entity"
+
 	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
 )
 
 // MockRepositoryPlaceholder is an autogenerated mock type for the RepositoryPlaceholder type
@@ -21,22 +26,34 @@ func (_m *MockRepositoryPlaceholder) EXPECT() *MockRepositoryPlaceholder_Expecte
 	return &MockRepositoryPlaceholder_Expecter{mock: &_m.Mock}
 }
 
-// Create provides a mock function with given fields: ctx
-func (_m *MockRepositoryPlaceholder) Create(ctx context.Context) error {
-	ret := _m.Called(ctx)
+// Create provides a mock function with given fields: ctx, name
+func (_m *MockRepositoryPlaceholder) Create(ctx context.Context, name string) (uuid.UUID, error) {
+	ret := _m.Called(ctx, name)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Create")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
-		r0 = rf(ctx)
+	var r0 uuid.UUID
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (uuid.UUID, error)); ok {
+		return rf(ctx, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) uuid.UUID); ok {
+		r0 = rf(ctx, name)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(uuid.UUID)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // MockRepositoryPlaceholder_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
@@ -46,29 +63,30 @@ type MockRepositoryPlaceholder_Create_Call struct {
 
 // Create is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *MockRepositoryPlaceholder_Expecter) Create(ctx interface{}) *MockRepositoryPlaceholder_Create_Call {
-	return &MockRepositoryPlaceholder_Create_Call{Call: _e.mock.On("Create", ctx)}
+//   - name string
+func (_e *MockRepositoryPlaceholder_Expecter) Create(ctx interface{}, name interface{}) *MockRepositoryPlaceholder_Create_Call {
+	return &MockRepositoryPlaceholder_Create_Call{Call: _e.mock.On("Create", ctx, name)}
 }
 
-func (_c *MockRepositoryPlaceholder_Create_Call) Run(run func(ctx context.Context)) *MockRepositoryPlaceholder_Create_Call {
+func (_c *MockRepositoryPlaceholder_Create_Call) Run(run func(ctx context.Context, name string)) *MockRepositoryPlaceholder_Create_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context))
+		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *MockRepositoryPlaceholder_Create_Call) Return(_a0 error) *MockRepositoryPlaceholder_Create_Call {
-	_c.Call.Return(_a0)
+func (_c *MockRepositoryPlaceholder_Create_Call) Return(_a0 uuid.UUID, _a1 error) *MockRepositoryPlaceholder_Create_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockRepositoryPlaceholder_Create_Call) RunAndReturn(run func(context.Context) error) *MockRepositoryPlaceholder_Create_Call {
+func (_c *MockRepositoryPlaceholder_Create_Call) RunAndReturn(run func(context.Context, string) (uuid.UUID, error)) *MockRepositoryPlaceholder_Create_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // Delete provides a mock function with given fields: ctx, id
-func (_m *MockRepositoryPlaceholder) Delete(ctx context.Context, id int64) error {
+func (_m *MockRepositoryPlaceholder) Delete(ctx context.Context, id uuid.UUID) error {
 	ret := _m.Called(ctx, id)
 
 	if len(ret) == 0 {
@@ -76,7 +94,7 @@ func (_m *MockRepositoryPlaceholder) Delete(ctx context.Context, id int64) error
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
 		r0 = rf(ctx, id)
 	} else {
 		r0 = ret.Error(0)
@@ -92,14 +110,14 @@ type MockRepositoryPlaceholder_Delete_Call struct {
 
 // Delete is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id int64
+//   - id uuid.UUID
 func (_e *MockRepositoryPlaceholder_Expecter) Delete(ctx interface{}, id interface{}) *MockRepositoryPlaceholder_Delete_Call {
 	return &MockRepositoryPlaceholder_Delete_Call{Call: _e.mock.On("Delete", ctx, id)}
 }
 
-func (_c *MockRepositoryPlaceholder_Delete_Call) Run(run func(ctx context.Context, id int64)) *MockRepositoryPlaceholder_Delete_Call {
+func (_c *MockRepositoryPlaceholder_Delete_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockRepositoryPlaceholder_Delete_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(int64))
+		run(args[0].(context.Context), args[1].(uuid.UUID))
 	})
 	return _c
 }
@@ -109,27 +127,37 @@ func (_c *MockRepositoryPlaceholder_Delete_Call) Return(_a0 error) *MockReposito
 	return _c
 }
 
-func (_c *MockRepositoryPlaceholder_Delete_Call) RunAndReturn(run func(context.Context, int64) error) *MockRepositoryPlaceholder_Delete_Call {
+func (_c *MockRepositoryPlaceholder_Delete_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *MockRepositoryPlaceholder_Delete_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // Get provides a mock function with given fields: ctx, id
-func (_m *MockRepositoryPlaceholder) Get(ctx context.Context, id int64) error {
+func (_m *MockRepositoryPlaceholder) Get(ctx context.Context, id uuid.UUID) (entity.Placeholder, error) {
 	ret := _m.Called(ctx, id)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Get")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+	var r0 entity.Placeholder
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (entity.Placeholder, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) entity.Placeholder); ok {
 		r0 = rf(ctx, id)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(entity.Placeholder)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // MockRepositoryPlaceholder_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
@@ -139,44 +167,54 @@ type MockRepositoryPlaceholder_Get_Call struct {
 
 // Get is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id int64
+//   - id uuid.UUID
 func (_e *MockRepositoryPlaceholder_Expecter) Get(ctx interface{}, id interface{}) *MockRepositoryPlaceholder_Get_Call {
 	return &MockRepositoryPlaceholder_Get_Call{Call: _e.mock.On("Get", ctx, id)}
 }
 
-func (_c *MockRepositoryPlaceholder_Get_Call) Run(run func(ctx context.Context, id int64)) *MockRepositoryPlaceholder_Get_Call {
+func (_c *MockRepositoryPlaceholder_Get_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockRepositoryPlaceholder_Get_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(int64))
+		run(args[0].(context.Context), args[1].(uuid.UUID))
 	})
 	return _c
 }
 
-func (_c *MockRepositoryPlaceholder_Get_Call) Return(_a0 error) *MockRepositoryPlaceholder_Get_Call {
-	_c.Call.Return(_a0)
+func (_c *MockRepositoryPlaceholder_Get_Call) Return(_a0 entity.Placeholder, _a1 error) *MockRepositoryPlaceholder_Get_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockRepositoryPlaceholder_Get_Call) RunAndReturn(run func(context.Context, int64) error) *MockRepositoryPlaceholder_Get_Call {
+func (_c *MockRepositoryPlaceholder_Get_Call) RunAndReturn(run func(context.Context, uuid.UUID) (entity.Placeholder, error)) *MockRepositoryPlaceholder_Get_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// List provides a mock function with given fields: ctx
-func (_m *MockRepositoryPlaceholder) List(ctx context.Context) error {
-	ret := _m.Called(ctx)
+// List provides a mock function with given fields: ctx, opts
+func (_m *MockRepositoryPlaceholder) List(ctx context.Context, opts biz.ListOptions) (biz.Page[entity.Placeholder], error) {
+	ret := _m.Called(ctx, opts)
 
 	if len(ret) == 0 {
 		panic("no return value specified for List")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
-		r0 = rf(ctx)
+	var r0 biz.Page[entity.Placeholder]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, biz.ListOptions) (biz.Page[entity.Placeholder], error)); ok {
+		return rf(ctx, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, biz.ListOptions) biz.Page[entity.Placeholder]); ok {
+		r0 = rf(ctx, opts)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(biz.Page[entity.Placeholder])
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, biz.ListOptions) error); ok {
+		r1 = rf(ctx, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // MockRepositoryPlaceholder_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
@@ -186,38 +224,39 @@ type MockRepositoryPlaceholder_List_Call struct {
 
 // List is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *MockRepositoryPlaceholder_Expecter) List(ctx interface{}) *MockRepositoryPlaceholder_List_Call {
-	return &MockRepositoryPlaceholder_List_Call{Call: _e.mock.On("List", ctx)}
+//   - opts biz.ListOptions
+func (_e *MockRepositoryPlaceholder_Expecter) List(ctx interface{}, opts interface{}) *MockRepositoryPlaceholder_List_Call {
+	return &MockRepositoryPlaceholder_List_Call{Call: _e.mock.On("List", ctx, opts)}
 }
 
-func (_c *MockRepositoryPlaceholder_List_Call) Run(run func(ctx context.Context)) *MockRepositoryPlaceholder_List_Call {
+func (_c *MockRepositoryPlaceholder_List_Call) Run(run func(ctx context.Context, opts biz.ListOptions)) *MockRepositoryPlaceholder_List_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context))
+		run(args[0].(context.Context), args[1].(biz.ListOptions))
 	})
 	return _c
 }
 
-func (_c *MockRepositoryPlaceholder_List_Call) Return(_a0 error) *MockRepositoryPlaceholder_List_Call {
-	_c.Call.Return(_a0)
+func (_c *MockRepositoryPlaceholder_List_Call) Return(_a0 biz.Page[entity.Placeholder], _a1 error) *MockRepositoryPlaceholder_List_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockRepositoryPlaceholder_List_Call) RunAndReturn(run func(context.Context) error) *MockRepositoryPlaceholder_List_Call {
+func (_c *MockRepositoryPlaceholder_List_Call) RunAndReturn(run func(context.Context, biz.ListOptions) (biz.Page[entity.Placeholder], error)) *MockRepositoryPlaceholder_List_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Update provides a mock function with given fields: ctx, id
-func (_m *MockRepositoryPlaceholder) Update(ctx context.Context, id int64) error {
-	ret := _m.Called(ctx, id)
+// Update provides a mock function with given fields: ctx, id, name
+func (_m *MockRepositoryPlaceholder) Update(ctx context.Context, id uuid.UUID, name string) error {
+	ret := _m.Called(ctx, id, name)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Update")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
-		r0 = rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) error); ok {
+		r0 = rf(ctx, id, name)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -232,14 +271,15 @@ type MockRepositoryPlaceholder_Update_Call struct {
 
 // Update is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id int64
-func (_e *MockRepositoryPlaceholder_Expecter) Update(ctx interface{}, id interface{}) *MockRepositoryPlaceholder_Update_Call {
-	return &MockRepositoryPlaceholder_Update_Call{Call: _e.mock.On("Update", ctx, id)}
+//   - id uuid.UUID
+//   - name string
+func (_e *MockRepositoryPlaceholder_Expecter) Update(ctx interface{}, id interface{}, name interface{}) *MockRepositoryPlaceholder_Update_Call {
+	return &MockRepositoryPlaceholder_Update_Call{Call: _e.mock.On("Update", ctx, id, name)}
 }
 
-func (_c *MockRepositoryPlaceholder_Update_Call) Run(run func(ctx context.Context, id int64)) *MockRepositoryPlaceholder_Update_Call {
+func (_c *MockRepositoryPlaceholder_Update_Call) Run(run func(ctx context.Context, id uuid.UUID, name string)) *MockRepositoryPlaceholder_Update_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(int64))
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string))
 	})
 	return _c
 }
@@ -249,7 +289,7 @@ func (_c *MockRepositoryPlaceholder_Update_Call) Return(_a0 error) *MockReposito
 	return _c
 }
 
-func (_c *MockRepositoryPlaceholder_Update_Call) RunAndReturn(run func(context.Context, int64) error) *MockRepositoryPlaceholder_Update_Call {
+func (_c *MockRepositoryPlaceholder_Update_Call) RunAndReturn(run func(context.Context, uuid.UUID, string) error) *MockRepositoryPlaceholder_Update_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -3,6 +3,7 @@
 package mocks
 
 import (
+	entity "application/internal/entity"
 	context "context"
 
 	mock "github.com/stretchr/testify/mock"
@@ -67,6 +68,52 @@ func (_c *MockUsecaseHealthzer_Liveness_Call) RunAndReturn(run func(context.Cont
 	return _c
 }
 
+// LivenessReport provides a mock function with given fields: ctx
+func (_m *MockUsecaseHealthzer) LivenessReport(ctx context.Context) entity.HealthReport {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LivenessReport")
+	}
+
+	var r0 entity.HealthReport
+	if rf, ok := ret.Get(0).(func(context.Context) entity.HealthReport); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(entity.HealthReport)
+	}
+
+	return r0
+}
+
+// MockUsecaseHealthzer_LivenessReport_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LivenessReport'
+type MockUsecaseHealthzer_LivenessReport_Call struct {
+	*mock.Call
+}
+
+// LivenessReport is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockUsecaseHealthzer_Expecter) LivenessReport(ctx interface{}) *MockUsecaseHealthzer_LivenessReport_Call {
+	return &MockUsecaseHealthzer_LivenessReport_Call{Call: _e.mock.On("LivenessReport", ctx)}
+}
+
+func (_c *MockUsecaseHealthzer_LivenessReport_Call) Run(run func(ctx context.Context)) *MockUsecaseHealthzer_LivenessReport_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockUsecaseHealthzer_LivenessReport_Call) Return(_a0 entity.HealthReport) *MockUsecaseHealthzer_LivenessReport_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockUsecaseHealthzer_LivenessReport_Call) RunAndReturn(run func(context.Context) entity.HealthReport) *MockUsecaseHealthzer_LivenessReport_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Readiness provides a mock function with given fields: ctx
 func (_m *MockUsecaseHealthzer) Readiness(ctx context.Context) error {
 	ret := _m.Called(ctx)
@@ -113,6 +160,144 @@ func (_c *MockUsecaseHealthzer_Readiness_Call) RunAndReturn(run func(context.Con
 	return _c
 }
 
+// ReadinessReport provides a mock function with given fields: ctx
+func (_m *MockUsecaseHealthzer) ReadinessReport(ctx context.Context) entity.HealthReport {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReadinessReport")
+	}
+
+	var r0 entity.HealthReport
+	if rf, ok := ret.Get(0).(func(context.Context) entity.HealthReport); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(entity.HealthReport)
+	}
+
+	return r0
+}
+
+// MockUsecaseHealthzer_ReadinessReport_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReadinessReport'
+type MockUsecaseHealthzer_ReadinessReport_Call struct {
+	*mock.Call
+}
+
+// ReadinessReport is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockUsecaseHealthzer_Expecter) ReadinessReport(ctx interface{}) *MockUsecaseHealthzer_ReadinessReport_Call {
+	return &MockUsecaseHealthzer_ReadinessReport_Call{Call: _e.mock.On("ReadinessReport", ctx)}
+}
+
+func (_c *MockUsecaseHealthzer_ReadinessReport_Call) Run(run func(ctx context.Context)) *MockUsecaseHealthzer_ReadinessReport_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockUsecaseHealthzer_ReadinessReport_Call) Return(_a0 entity.HealthReport) *MockUsecaseHealthzer_ReadinessReport_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockUsecaseHealthzer_ReadinessReport_Call) RunAndReturn(run func(context.Context) entity.HealthReport) *MockUsecaseHealthzer_ReadinessReport_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Startup provides a mock function with given fields: ctx
+func (_m *MockUsecaseHealthzer) Startup(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Startup")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockUsecaseHealthzer_Startup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Startup'
+type MockUsecaseHealthzer_Startup_Call struct {
+	*mock.Call
+}
+
+// Startup is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockUsecaseHealthzer_Expecter) Startup(ctx interface{}) *MockUsecaseHealthzer_Startup_Call {
+	return &MockUsecaseHealthzer_Startup_Call{Call: _e.mock.On("Startup", ctx)}
+}
+
+func (_c *MockUsecaseHealthzer_Startup_Call) Run(run func(ctx context.Context)) *MockUsecaseHealthzer_Startup_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockUsecaseHealthzer_Startup_Call) Return(_a0 error) *MockUsecaseHealthzer_Startup_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockUsecaseHealthzer_Startup_Call) RunAndReturn(run func(context.Context) error) *MockUsecaseHealthzer_Startup_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StartupReport provides a mock function with given fields: ctx
+func (_m *MockUsecaseHealthzer) StartupReport(ctx context.Context) entity.HealthReport {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StartupReport")
+	}
+
+	var r0 entity.HealthReport
+	if rf, ok := ret.Get(0).(func(context.Context) entity.HealthReport); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(entity.HealthReport)
+	}
+
+	return r0
+}
+
+// MockUsecaseHealthzer_StartupReport_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StartupReport'
+type MockUsecaseHealthzer_StartupReport_Call struct {
+	*mock.Call
+}
+
+// StartupReport is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockUsecaseHealthzer_Expecter) StartupReport(ctx interface{}) *MockUsecaseHealthzer_StartupReport_Call {
+	return &MockUsecaseHealthzer_StartupReport_Call{Call: _e.mock.On("StartupReport", ctx)}
+}
+
+func (_c *MockUsecaseHealthzer_StartupReport_Call) Run(run func(ctx context.Context)) *MockUsecaseHealthzer_StartupReport_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockUsecaseHealthzer_StartupReport_Call) Return(_a0 entity.HealthReport) *MockUsecaseHealthzer_StartupReport_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockUsecaseHealthzer_StartupReport_Call) RunAndReturn(run func(context.Context) entity.HealthReport) *MockUsecaseHealthzer_StartupReport_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockUsecaseHealthzer creates a new instance of MockUsecaseHealthzer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockUsecaseHealthzer(t interface {
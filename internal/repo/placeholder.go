@@ -5,7 +5,10 @@ import (
 	"application/internal/datasource"
 	"application/internal/entity"
 	"context"
+	"fmt"
 	"log/slog"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"go.opentelemetry.io/otel"
@@ -48,44 +51,170 @@ func (r *placeholder) Create(ctx context.Context, name string) (uuid.UUID, error
 	return id, nil
 }
 
-// List implements biz.RepositoryPlaceholder.
-func (r *placeholder) List(ctx context.Context) ([]entity.Placeholder, error) {
+// placeholderSortColumns and placeholderFilterColumns map the fields of
+// biz.ListOptions to columns, keeping anything else out of the query.
+var (
+	placeholderSortColumns = map[string]string{
+		"created_at": "created_at",
+		"name":       "name",
+	}
+	placeholderFilterColumns = map[string]string{
+		"name": "name",
+	}
+)
+
+// likeEscaper escapes the LIKE wildcards of a filter value.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// List implements biz.RepositoryPlaceholder. Pages are read by keyset on the
+// sort column and id, so a page costs the same however deep it is.
+func (r *placeholder) List(ctx context.Context, opts biz.ListOptions) (biz.Page[entity.Placeholder], error) {
 	logger := r.logger.With("method", "List")
-	query := `SELECT id, name FROM placeholder`
 
-	rows, err := r.db.QueryContext(ctx, query)
+	var page biz.Page[entity.Placeholder]
+
+	column, ok := placeholderSortColumns[opts.Sort]
+	if !ok {
+		return page, fmt.Errorf("%w: cannot sort by %q", biz.ErrResourceInvalid, opts.Sort)
+	}
+
+	var (
+		where []string
+		args  []any
+	)
+
+	for _, f := range opts.Filters {
+		filterColumn, ok := placeholderFilterColumns[f.Field]
+		if !ok {
+			return page, fmt.Errorf("%w: cannot filter by %q", biz.ErrResourceInvalid, f.Field)
+		}
+
+		pattern := likeEscaper.Replace(f.Value) + "%"
+		if f.Op == biz.FilterContains {
+			pattern = "%" + pattern
+		}
+
+		args = append(args, pattern)
+		where = append(where, fmt.Sprintf("%s LIKE $%d", filterColumn, len(args)))
+	}
+
+	db := r.db.ReadOnly(ctx)
+
+	if opts.WithTotal {
+		var total int
+		if err := db.QueryRowContext(ctx, `SELECT count(*) FROM placeholder`+whereClause(where), args...).Scan(&total); err != nil {
+			logger.WarnContext(ctx, "failed to count rows", "error", err)
+
+			return page, err
+		}
+
+		page.Total = &total
+	}
+
+	cursor, err := opts.DecodeCursor()
+	if err != nil {
+		return page, err
+	}
+
+	if cursor != nil {
+		value, id, err := placeholderCursor(column, cursor)
+		if err != nil {
+			return page, err
+		}
+
+		op := ">"
+		if opts.Desc {
+			op = "<"
+		}
+
+		args = append(args, value, id)
+		where = append(where, fmt.Sprintf("(%s, id) %s ($%d, $%d)", column, op, len(args)-1, len(args)))
+	}
+
+	direction := "ASC"
+	if opts.Desc {
+		direction = "DESC"
+	}
+
+	// One row more than the page tells whether another page follows.
+	query := `SELECT id, name, created_at FROM placeholder` + whereClause(where) +
+		fmt.Sprintf(` ORDER BY %s %s, id %s LIMIT %d`, column, direction, direction, opts.Limit+1)
+
+	rows, err := db.QueryContext(ctx, query, args...)
 	if err != nil {
 		logger.WarnContext(ctx, "failed to execute query", "error", err)
 
-		return nil, err
+		return page, err
 	}
 	defer rows.Close()
 
-	var placeholders []entity.Placeholder
+	page.Items = make([]entity.Placeholder, 0, opts.Limit+1)
 
 	for rows.Next() {
 		var p entity.Placeholder
-		if err := rows.Scan(&p.ID, &p.Name); err != nil {
+		if err := rows.Scan(&p.ID, &p.Name, &p.CreatedAt); err != nil {
 			logger.WarnContext(ctx, "failed to scan row", "error", err)
 
-			continue
+			return biz.Page[entity.Placeholder]{}, err
 		}
 
-		placeholders = append(placeholders, p)
+		page.Items = append(page.Items, p)
 	}
 
+	// A truncated result would pass for the last page.
 	if err := rows.Err(); err != nil {
 		logger.WarnContext(ctx, "rows iteration error", "error", err)
+
+		return biz.Page[entity.Placeholder]{}, err
+	}
+
+	if len(page.Items) > opts.Limit {
+		page.Items = page.Items[:opts.Limit]
+		last := page.Items[len(page.Items)-1]
+
+		next := biz.Cursor{Sort: opts.Sort, Desc: opts.Desc, Value: last.Name, ID: last.ID.String()}
+		if column == "created_at" {
+			next.Value = last.CreatedAt.Format(time.RFC3339Nano)
+		}
+
+		page.NextCursor = next.Encode()
+	}
+
+	return page, nil
+}
+
+// placeholderCursor returns the sort column value and id a cursor points after.
+func placeholderCursor(column string, cursor *biz.Cursor) (any, uuid.UUID, error) {
+	id, err := uuid.Parse(cursor.ID)
+	if err != nil {
+		return nil, uuid.Nil, fmt.Errorf("%w: malformed cursor", biz.ErrResourceInvalid)
+	}
+
+	if column != "created_at" {
+		return cursor.Value, id, nil
+	}
+
+	createdAt, err := time.Parse(time.RFC3339Nano, cursor.Value)
+	if err != nil {
+		return nil, uuid.Nil, fmt.Errorf("%w: malformed cursor", biz.ErrResourceInvalid)
+	}
+
+	return createdAt, id, nil
+}
+
+func whereClause(conditions []string) string {
+	if len(conditions) == 0 {
+		return ""
 	}
 
-	return placeholders, nil
+	return " WHERE " + strings.Join(conditions, " AND ")
 }
 
 // Get implements biz.RepositoryPlaceholder.
 func (r *placeholder) Get(ctx context.Context, id uuid.UUID) (entity.Placeholder, error) {
 	logger := r.logger.With("method", "Get")
-	query := `SELECT id, name FROM placeholder WHERE id = $1`
-	row := r.db.QueryRowContext(ctx, query, id)
+	query := `SELECT id, name, created_at FROM placeholder WHERE id = $1`
+	row := r.db.ReadOnly(ctx).QueryRowContext(ctx, query, id)
 
 	if row.Err() != nil {
 		logger.WarnContext(ctx, "failed to execute query", "error", row.Err())
@@ -94,7 +223,7 @@ func (r *placeholder) Get(ctx context.Context, id uuid.UUID) (entity.Placeholder
 	}
 
 	var p entity.Placeholder
-	if err := row.Scan(&p.ID, &p.Name); err != nil {
+	if err := row.Scan(&p.ID, &p.Name, &p.CreatedAt); err != nil {
 		logger.WarnContext(ctx, "failed to scan row", "error", err)
 
 		return entity.Placeholder{}, err
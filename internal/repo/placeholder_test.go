@@ -0,0 +1,158 @@
+package repo_test
+
+import (
+	"application/internal/biz"
+	"application/internal/datasource"
+	"application/internal/datasource/sqltest"
+	"application/internal/entity"
+	"application/internal/repo"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// listPlaceholders runs List on a recorder returning rows placeholders and a
+// count of 42, and returns the page and the statements run.
+func listPlaceholders(t *testing.T, opts biz.ListOptions, rows int) (biz.Page[entity.Placeholder], []sqltest.Statement) {
+	t.Helper()
+
+	created := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	rec := &sqltest.Recorder{Query: func(query string, _ []any) (driver.Rows, error) {
+		if strings.HasPrefix(query, "SELECT count(*)") {
+			return sqltest.Rows([]string{"count"}, []driver.Value{int64(42)}), nil
+		}
+
+		values := make([][]driver.Value, rows)
+		for i := range values {
+			values[i] = []driver.Value{uuid.NewString(), "placeholder", created.Add(-time.Duration(i) * time.Second)}
+		}
+
+		return sqltest.Rows([]string{"id", "name", "created_at"}, values...), nil
+	}}
+
+	db := sql.OpenDB(rec)
+	t.Cleanup(func() { _ = db.Close() })
+
+	r := repo.NewPlaceholder(slog.New(slog.DiscardHandler), datasource.NewPostgresDBFromDB(db))
+
+	page, err := r.List(context.Background(), opts)
+	require.NoError(t, err)
+
+	return page, rec.Statements()
+}
+
+func TestPlaceholderListKeyset(t *testing.T) {
+	after := biz.Cursor{
+		Sort:  "created_at",
+		Desc:  true,
+		Value: "2026-01-02T03:04:05Z",
+		ID:    "0195c1a4-7b2e-7c3d-9e8f-1a2b3c4d5e6f",
+	}
+
+	page, statements := listPlaceholders(t, biz.ListOptions{
+		Limit:     2,
+		Sort:      "created_at",
+		Desc:      true,
+		Cursor:    after.Encode(),
+		Filters:   []biz.Filter{{Field: "name", Op: biz.FilterContains, Value: `50%_off\`}},
+		WithTotal: true,
+	}, 3)
+	require.Len(t, statements, 2)
+
+	pattern := `%50\%\_off\\%`
+
+	assert.Equal(t, "SELECT count(*) FROM placeholder WHERE name LIKE $1", statements[0].Query)
+	assert.Equal(t, []any{pattern}, statements[0].Args, "the LIKE wildcards of the value are escaped")
+
+	assert.Equal(t, "SELECT id, name, created_at FROM placeholder WHERE name LIKE $1 AND (created_at, id) < ($2, $3)"+
+		" ORDER BY created_at DESC, id DESC LIMIT 3", statements[1].Query)
+	assert.Equal(t, []any{pattern, time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC), after.ID}, statements[1].Args)
+
+	require.Len(t, page.Items, 2, "the extra row only tells another page follows")
+	require.NotNil(t, page.Total)
+	assert.Equal(t, 42, *page.Total)
+
+	next, err := (&biz.ListOptions{Sort: "created_at", Desc: true, Cursor: page.NextCursor}).DecodeCursor()
+	require.NoError(t, err)
+	assert.Equal(t, page.Items[1].ID.String(), next.ID)
+	assert.Equal(t, page.Items[1].CreatedAt.Format(time.RFC3339Nano), next.Value)
+}
+
+func TestPlaceholderListAscending(t *testing.T) {
+	after := biz.Cursor{Sort: "name", Value: "b", ID: "0195c1a4-7b2e-7c3d-9e8f-1a2b3c4d5e6f"}
+
+	page, statements := listPlaceholders(t, biz.ListOptions{
+		Limit:   2,
+		Sort:    "name",
+		Cursor:  after.Encode(),
+		Filters: []biz.Filter{{Field: "name", Op: biz.FilterPrefix, Value: "a_b"}},
+	}, 2)
+	require.Len(t, statements, 1, "no count without WithTotal")
+
+	assert.Equal(t, "SELECT id, name, created_at FROM placeholder WHERE name LIKE $1 AND (name, id) > ($2, $3)"+
+		" ORDER BY name ASC, id ASC LIMIT 3", statements[0].Query)
+	assert.Equal(t, []any{`a\_b%`, "b", after.ID}, statements[0].Args)
+
+	assert.Len(t, page.Items, 2)
+	assert.Empty(t, page.NextCursor, "the last page")
+	assert.Nil(t, page.Total)
+}
+
+// truncatedRows fails after its first rows, as when the connection drops mid-result.
+type truncatedRows struct {
+	driver.Rows
+
+	left int
+	err  error
+}
+
+func (r *truncatedRows) Next(dest []driver.Value) error {
+	if r.left == 0 {
+		return r.err
+	}
+
+	r.left--
+
+	return r.Rows.Next(dest)
+}
+
+func TestPlaceholderListBrokenRows(t *testing.T) {
+	errDropped := errors.New("connection dropped")
+	created := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	for name, rows := range map[string]func() driver.Rows{
+		"scan": func() driver.Rows {
+			return sqltest.Rows([]string{"id", "name", "created_at"},
+				[]driver.Value{uuid.NewString(), "a", created},
+				[]driver.Value{"not a uuid", "b", created})
+		},
+		"iteration": func() driver.Rows {
+			return &truncatedRows{Rows: sqltest.Rows([]string{"id", "name", "created_at"},
+				[]driver.Value{uuid.NewString(), "a", created}), left: 1, err: errDropped}
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			rec := &sqltest.Recorder{Query: func(string, []any) (driver.Rows, error) { return rows(), nil }}
+
+			db := sql.OpenDB(rec)
+			t.Cleanup(func() { _ = db.Close() })
+
+			r := repo.NewPlaceholder(slog.New(slog.DiscardHandler), datasource.NewPostgresDBFromDB(db))
+
+			page, err := r.List(context.Background(), biz.ListOptions{Limit: 1, Sort: "name"})
+			require.Error(t, err, "a broken result is not a short last page")
+			assert.Empty(t, page.Items)
+			assert.Empty(t, page.NextCursor)
+		})
+	}
+}
@@ -35,6 +35,14 @@ var ErrorsMap = ErrorMap{
 		Message: "دسترسی غیرمجاز",
 		Code:    http.StatusUnauthorized,
 	},
+	biz.ErrServiceDraining: {
+		Message: "draining",
+		Code:    http.StatusServiceUnavailable,
+	},
+	biz.ErrServiceStarting: {
+		Message: "starting",
+		Code:    http.StatusServiceUnavailable,
+	},
 }
 
 func HandleError(err error, w http.ResponseWriter) {
@@ -0,0 +1,78 @@
+package dto
+
+import (
+	"application/internal/entity"
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// HealthContentType is the media type of HealthResponse.
+const HealthContentType = "application/health+json"
+
+// HealthResponse is the health check response format for HTTP APIs
+// (draft-inadarei-api-health-check).
+type HealthResponse struct {
+	Status string                           `json:"status"`
+	Output string                           `json:"output,omitempty"`
+	Checks map[string][]HealthCheckResponse `json:"checks,omitempty"`
+}
+
+// HealthCheckResponse is the result of one check; ObservedValue is its latency.
+type HealthCheckResponse struct {
+	ComponentID   string  `json:"componentId"`
+	Status        string  `json:"status"`
+	Severity      string  `json:"severity"`
+	ObservedValue float64 `json:"observedValue"`
+	ObservedUnit  string  `json:"observedUnit"`
+	Time          string  `json:"time"`
+	Output        string  `json:"output,omitempty"`
+}
+
+// ToHealthResponse converts an entity.HealthReport to a HealthResponse.
+func ToHealthResponse(report entity.HealthReport) *HealthResponse {
+	resp := &HealthResponse{
+		Status: string(report.Status),
+		Output: report.Output,
+	}
+
+	if len(report.Checks) == 0 {
+		return resp
+	}
+
+	resp.Checks = make(map[string][]HealthCheckResponse, len(report.Checks))
+
+	for _, check := range report.Checks {
+		c := HealthCheckResponse{
+			ComponentID:   check.Name,
+			Status:        string(check.Status),
+			Severity:      string(check.Severity),
+			ObservedValue: float64(check.Latency) / float64(time.Millisecond),
+			ObservedUnit:  "ms",
+			Time:          check.Time.UTC().Format(time.RFC3339Nano),
+		}
+
+		if check.Error != nil {
+			c.Output = check.Error.Error()
+		}
+
+		resp.Checks[check.Name] = append(resp.Checks[check.Name], c)
+	}
+
+	return resp
+}
+
+// HandleHealth writes report as application/health+json. A failed report
+// is answered with 503, pass and warn, which includes degraded, with 200.
+func HandleHealth(report entity.HealthReport, w http.ResponseWriter) {
+	w.Header().Set("Content-Type", HealthContentType)
+	w.Header().Set("Cache-Control", "no-store")
+
+	if report.Status == entity.HealthFail {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	} else {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	_ = json.NewEncoder(w).Encode(ToHealthResponse(report))
+}
@@ -0,0 +1,71 @@
+package dto
+
+import (
+	"application/internal/biz"
+	"fmt"
+	"maps"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// filterSuffixes maps the suffix of a filter query parameter, as in
+// name_prefix, to its biz.FilterOp.
+var filterSuffixes = map[string]biz.FilterOp{
+	"_prefix":   biz.FilterPrefix,
+	"_contains": biz.FilterContains,
+}
+
+// ParseListOptions reads the list query parameters: limit, cursor, sort,
+// order (asc or desc), total (a bool) and <field>_prefix or <field>_contains
+// filters. Which fields may be sorted and filtered by is up to the usecase.
+func ParseListOptions(query url.Values) (biz.ListOptions, error) {
+	opts := biz.ListOptions{
+		Cursor: query.Get("cursor"),
+		Sort:   query.Get("sort"),
+	}
+
+	if limit := query.Get("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil {
+			return opts, fmt.Errorf("%w: limit: %w", biz.ErrResourceInvalid, err)
+		}
+
+		opts.Limit = n
+	}
+
+	switch order := query.Get("order"); order {
+	case "", "asc":
+	case "desc":
+		opts.Desc = true
+	default:
+		return opts, fmt.Errorf("%w: order %q is not asc or desc", biz.ErrResourceInvalid, order)
+	}
+
+	if total := query.Get("total"); total != "" {
+		withTotal, err := strconv.ParseBool(total)
+		if err != nil {
+			return opts, fmt.Errorf("%w: total: %w", biz.ErrResourceInvalid, err)
+		}
+
+		opts.WithTotal = withTotal
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(query)) {
+		values := query[key]
+
+		for suffix, op := range filterSuffixes {
+			field, ok := strings.CutSuffix(key, suffix)
+			if !ok || field == "" {
+				continue
+			}
+
+			for _, value := range values {
+				opts.Filters = append(opts.Filters, biz.Filter{Field: field, Op: op, Value: value})
+			}
+		}
+	}
+
+	return opts, nil
+}
@@ -1,6 +1,10 @@
 package dto
 
-import "application/internal/entity"
+import (
+	"application/internal/biz"
+	"application/internal/entity"
+	"time"
+)
 
 // CreatePlaceholderReq is the request DTO for creating a placeholder.
 type CreatePlaceholderReq struct {
@@ -14,8 +18,9 @@ type UpdatePlaceholderReq struct {
 
 // PlaceholderResp is the response DTO for a placeholder.
 type PlaceholderResp struct {
-	ID   string `json:"id"`
-	Name string `json:"name"`
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at,omitzero"`
 }
 
 // ToPlaceholderResp converts an entity.Placeholder to a PlaceholderResp.
@@ -25,29 +30,33 @@ func ToPlaceholderResp(e *entity.Placeholder) *PlaceholderResp {
 	}
 
 	return &PlaceholderResp{
-		ID:   e.ID.String(),
-		Name: e.Name,
+		ID:        e.ID.String(),
+		Name:      e.Name,
+		CreatedAt: e.CreatedAt,
 	}
 }
 
+// PlaceholderListResponse is one page of placeholders.
 type PlaceholderListResponse struct {
 	Count        int                `json:"count"`
 	Placeholders []*PlaceholderResp `json:"placeholders"`
+	// NextCursor is passed as cursor to get the next page; omitted on the last one.
+	NextCursor string `json:"next_cursor,omitempty"`
+	// Total is the number of placeholders matching the filters, with total=true.
+	Total *int `json:"total,omitempty"`
 }
 
-// ToPlaceholderResps converts a slice of entity.Placeholder to a slice of PlaceholderResp.
-func ToPlaceholderResps(es []entity.Placeholder) *PlaceholderListResponse {
-	if es == nil {
-		return nil
-	}
-
-	resps := make([]*PlaceholderResp, 0, len(es))
-	for i := range es {
-		resps = append(resps, ToPlaceholderResp(&es[i]))
+// ToPlaceholderListResponse converts a page of entity.Placeholder to a PlaceholderListResponse.
+func ToPlaceholderListResponse(page biz.Page[entity.Placeholder]) *PlaceholderListResponse {
+	resps := make([]*PlaceholderResp, 0, len(page.Items))
+	for i := range page.Items {
+		resps = append(resps, ToPlaceholderResp(&page.Items[i]))
 	}
 
 	return &PlaceholderListResponse{
 		Count:        len(resps),
 		Placeholders: resps,
+		NextCursor:   page.NextCursor,
+		Total:        page.Total,
 	}
 }
@@ -1,9 +1,11 @@
 package service
 
 import (
+	"application/app"
 	"context"
 	"log/slog"
 	"net/http"
+	"net/http/pprof"
 
 	_ "application/docs" // Import generated docs
 
@@ -12,12 +14,23 @@ import (
 	"github.com/swaggo/swag"
 )
 
+// AdminMux is the mux of the admin server, which hosts metrics, pprof,
+// healthz and the API documentation.
+type AdminMux struct {
+	*http.ServeMux
+}
+
+func NewAdminMux() *AdminMux {
+	return &AdminMux{ServeMux: http.NewServeMux()}
+}
+
+// NewHTTPHandler registers every service and returns the public API handler.
 func NewHTTPHandler(
 	ctx context.Context,
 	logger *slog.Logger,
 	mux *http.ServeMux,
 	svcs ...Handler,
-) (http.Handler, error) {
+) (app.PublicHTTPHandler, error) {
 	for _, svc := range svcs {
 		if err := svc.RegisterHandler(ctx); err != nil {
 			logger.Error("failed to register handler", "err", err)
@@ -26,8 +39,24 @@ func NewHTTPHandler(
 		}
 	}
 
+	return mux, nil
+}
+
+// NewAdminHandler registers the operational endpoints on the admin mux.
+func NewAdminHandler(
+	ctx context.Context,
+	logger *slog.Logger,
+	mux *AdminMux,
+) (app.AdminHTTPHandler, error) {
 	mux.Handle("/metrics", promhttp.Handler())
 
+	// Never shared with the public listener, see app.AdminOnlyPathPrefix.
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+
 	doc, err := swag.ReadDoc("")
 	if err != nil {
 		logger.Error("failed to read swagger doc", "err", err)
@@ -0,0 +1,99 @@
+package service_test
+
+import (
+	"application/app"
+	"application/internal/mocks"
+	"application/internal/service"
+	"context"
+	"log/slog"
+	"net/http"
+	"testing"
+
+	"github.com/knadh/koanf/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// startServers serves a public route and the admin routes with the given
+// server settings and returns the base URL of each server by name.
+func startServers(t *testing.T, settings map[string]any) map[string]string {
+	t.Helper()
+
+	ctx := context.Background()
+	logger := slog.New(slog.DiscardHandler)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /apis/ping", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	public, err := service.NewHTTPHandler(ctx, logger, mux)
+	require.NoError(t, err)
+
+	admin, err := service.NewAdminHandler(ctx, logger, service.NewAdminMux())
+	require.NoError(t, err)
+
+	k := koanf.New(".")
+
+	for key, value := range settings {
+		require.NoError(t, k.Set(key, value))
+	}
+
+	cfg, err := app.NewHTTPServerConfig(ctx, app.NewKConfig(k))
+	require.NoError(t, err)
+
+	appLogger := mocks.NewMockAppLogger(t)
+	appLogger.EXPECT().GetLogger().Return(logger).Maybe()
+
+	urls := map[string]string{}
+
+	for _, s := range app.NewHTTPServers(cfg, public, admin, appLogger, app.NewController()) {
+		require.NoError(t, s.Start(ctx))
+		t.Cleanup(func() { _ = s.Shutdown(context.Background()) })
+
+		urls[s.Name()] = "http://" + s.Addr().String()
+	}
+
+	return urls
+}
+
+func statusOf(t *testing.T, url string) int {
+	t.Helper()
+
+	resp, err := http.Get(url)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	return resp.StatusCode
+}
+
+func TestAdminRoutesOnPublicServer(t *testing.T) {
+	urls := startServers(t, map[string]any{"server.http.addr": "127.0.0.1:0"})
+	require.Len(t, urls, 1, "no admin server without an admin address")
+
+	public := urls[app.PublicHTTPServerName]
+
+	assert.Equal(t, http.StatusOK, statusOf(t, public+"/apis/ping"))
+	assert.Equal(t, http.StatusOK, statusOf(t, public+"/metrics"))
+	assert.Equal(t, http.StatusNotFound, statusOf(t, public+"/debug/pprof/"), "pprof needs an admin server")
+	assert.Equal(t, http.StatusNotFound, statusOf(t, public+"/debug/pprof/cmdline"), "pprof needs an admin server")
+	assert.Equal(t, http.StatusOK, statusOf(t, public+"/docs/swagger/swagger.json"))
+}
+
+func TestAdminRoutesOnAdminServer(t *testing.T) {
+	urls := startServers(t, map[string]any{
+		"server.http.addr":  "127.0.0.1:0",
+		"server.admin.addr": "127.0.0.1:0",
+	})
+	require.Len(t, urls, 2)
+
+	public, admin := urls[app.PublicHTTPServerName], urls[app.AdminHTTPServerName]
+
+	assert.Equal(t, http.StatusOK, statusOf(t, public+"/apis/ping"))
+	assert.Equal(t, http.StatusNotFound, statusOf(t, admin+"/apis/ping"))
+
+	for _, path := range []string{"/metrics", "/debug/pprof/", "/docs/swagger/swagger.json"} {
+		assert.Equal(t, http.StatusNotFound, statusOf(t, public+path), "%s is not public", path)
+		assert.Equal(t, http.StatusOK, statusOf(t, admin+path), path)
+	}
+}
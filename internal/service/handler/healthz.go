@@ -2,6 +2,7 @@ package handler
 
 import (
 	"application/internal/biz"
+	"application/internal/entity"
 	"application/internal/service"
 	"application/internal/service/dto"
 	"application/pkg/middlewares"
@@ -10,6 +11,7 @@ import (
 	"log/slog"
 	"net/http"
 	"reflect"
+	"strconv"
 	"time"
 
 	"go.opentelemetry.io/otel"
@@ -31,7 +33,7 @@ var _ service.Handler = (*HealthzHandler)(nil)
 func NewMuxHealthzHandler(
 	uc biz.UsecaseHealthzer,
 	logger *slog.Logger,
-	mux *http.ServeMux,
+	mux *service.AdminMux,
 ) *HealthzHandler {
 	return &HealthzHandler{
 		logger: logger.With("layer", "MuxHealthzService"),
@@ -39,7 +41,7 @@ func NewMuxHealthzHandler(
 		tracer: otel.Tracer(
 			reflect.TypeOf(HealthzHandler{}).String(),
 		),
-		mux: mux,
+		mux: mux.ServeMux,
 	}
 }
 
@@ -69,6 +71,10 @@ func (s *HealthzHandler) RegisterHandler(_ context.Context) error {
 		"GET /healthz/readiness",
 		middlewares.MultipleMiddleware(s.healthzReadiness, healthzMiddleware...),
 	)
+	s.mux.HandleFunc(
+		"GET /healthz/startup",
+		middlewares.MultipleMiddleware(s.healthzStartup, healthzMiddleware...),
+	)
 	s.mux.HandleFunc(
 		"GET /healthz/panic",
 		middlewares.MultipleMiddleware(s.panic, otherMiddleware...),
@@ -84,11 +90,14 @@ func (s *HealthzHandler) RegisterHandler(_ context.Context) error {
 // HealthzLiveness
 //
 //	@Summary		Healthz Liveness
-//	@Description	Check the liveness of the service
+//	@Description	Check the liveness of the service. Every check is reported as application/health+json unless verbose is false.
 //	@ID				healthz-liveness
 //	@Accept			json
 //	@Produce		json
-//	@Success		200	"ok"
+//	@Produce		application/health+json
+//	@Param			verbose	query		bool				false	"Report every check"	default(true)
+//	@Success		200		{object}	dto.HealthResponse	"pass"
+//	@Failure		503		{object}	dto.HealthResponse	"fail"
 //	@Router			/healthz/liveness [get]
 //	@Tags			healthz
 func (s *HealthzHandler) healthzLiveness(w http.ResponseWriter, r *http.Request) {
@@ -105,10 +114,23 @@ func (s *HealthzHandler) healthzLiveness(w http.ResponseWriter, r *http.Request)
 
 	span.SetName("liveness")
 
-	w.Header().Set("Content-Type", "application/json")
-
 	logger.DebugContext(ctx, "Liveness")
 
+	if verbose(r) {
+		report := s.uc.LivenessReport(ctx)
+		if report.Status == entity.HealthFail {
+			span.SetStatus(otelCodes.Error, "fail")
+		} else {
+			span.SetStatus(otelCodes.Ok, "ok")
+		}
+
+		dto.HandleHealth(report, w)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
 	err := s.uc.Liveness(ctx)
 	if err != nil {
 		dto.HandleError(errors.New("service not available"), w)
@@ -123,12 +145,15 @@ func (s *HealthzHandler) healthzLiveness(w http.ResponseWriter, r *http.Request)
 // Healthz Readiness
 //
 //	@Summary		Healthz Readiness
-//	@Description	Check the readiness of the service
+//	@Description	Check the readiness of the service. Every check is reported as application/health+json unless verbose is false.
 //	@ID				healthz-rediness
 //	@Accept			json
 //	@Produce		json
-//	@Success		200	"ok"
-//	@Router			/healthz/rediness [get]
+//	@Produce		application/health+json
+//	@Param			verbose	query		bool				false	"Report every check"	default(true)
+//	@Success		200		{object}	dto.HealthResponse	"pass"
+//	@Failure		503		{object}	dto.HealthResponse	"fail or draining"
+//	@Router			/healthz/readiness [get]
 //	@Tags			healthz
 func (s *HealthzHandler) healthzReadiness(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -141,9 +166,30 @@ func (s *HealthzHandler) healthzReadiness(w http.ResponseWriter, r *http.Request
 
 	defer span.End()
 
+	if verbose(r) {
+		report := s.uc.ReadinessReport(ctx)
+		if report.Status == entity.HealthFail {
+			span.SetStatus(otelCodes.Error, "fail")
+		} else {
+			span.SetStatus(otelCodes.Ok, "ok")
+			logger.InfoContext(ctx, "Readiness ok")
+		}
+
+		dto.HandleHealth(report, w)
+
+		return
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 
 	err := s.uc.Readiness(ctx)
+	if errors.Is(err, biz.ErrServiceDraining) {
+		span.SetStatus(otelCodes.Error, "draining")
+		dto.HandleError(err, w)
+
+		return
+	}
+
 	if err != nil {
 		dto.HandleError(errors.New("service not available"), w)
 
@@ -155,6 +201,65 @@ func (s *HealthzHandler) healthzReadiness(w http.ResponseWriter, r *http.Request
 	dto.HandleError(nil, w)
 }
 
+// Healthz Startup
+//
+//	@Summary		Healthz Startup
+//	@Description	Check that the service finished starting. Every check is reported as application/health+json unless verbose is false.
+//	@ID				healthz-startup
+//	@Accept			json
+//	@Produce		json
+//	@Produce		application/health+json
+//	@Param			verbose	query		bool				false	"Report every check"	default(true)
+//	@Success		200		{object}	dto.HealthResponse	"pass"
+//	@Failure		503		{object}	dto.HealthResponse	"fail or starting"
+//	@Router			/healthz/startup [get]
+//	@Tags			healthz
+func (s *HealthzHandler) healthzStartup(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx, span := s.tracer.Start(ctx, "startup")
+	defer span.End()
+
+	if verbose(r) {
+		report := s.uc.StartupReport(ctx)
+		if report.Status == entity.HealthFail {
+			span.SetStatus(otelCodes.Error, "fail")
+		} else {
+			span.SetStatus(otelCodes.Ok, "ok")
+		}
+
+		dto.HandleHealth(report, w)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	err := s.uc.Startup(ctx)
+	if errors.Is(err, biz.ErrServiceStarting) {
+		span.SetStatus(otelCodes.Error, "starting")
+		dto.HandleError(err, w)
+
+		return
+	}
+
+	if err != nil {
+		dto.HandleError(errors.New("service not available"), w)
+
+		return
+	}
+
+	span.SetStatus(otelCodes.Ok, "ok")
+	dto.HandleError(nil, w)
+}
+
+// verbose reports whether the per-check report is requested; only verbose=false turns it off.
+func verbose(r *http.Request) bool {
+	v, err := strconv.ParseBool(r.URL.Query().Get("verbose"))
+
+	return err != nil || v
+}
+
 // panic
 //
 //	@Router		/healthz/panic [get]
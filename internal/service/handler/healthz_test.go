@@ -1 +1,149 @@
 package handler_test
+
+import (
+	"application/app"
+	"application/internal/biz"
+	"application/internal/service"
+	"application/internal/service/dto"
+	"application/internal/service/handler"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errDown = errors.New("down")
+
+func newHealthzMux(t *testing.T, controller app.Controller) *service.AdminMux {
+	t.Helper()
+
+	logger := slog.New(slog.DiscardHandler)
+	mux := service.NewAdminMux()
+
+	h := handler.NewMuxHealthzHandler(biz.NewHealthz(logger, controller), logger, mux)
+	require.NoError(t, h.RegisterHandler(context.Background()))
+
+	return mux
+}
+
+func TestReadinessReport(t *testing.T) {
+	controller := app.NewController()
+	controller.RegisterHealthz("cache", func(context.Context) error { return nil })
+	controller.RegisterHealthz("db", func(context.Context) error { return errDown })
+
+	mux := newHealthzMux(t, controller)
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/healthz/readiness", nil))
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Equal(t, dto.HealthContentType, w.Header().Get("Content-Type"))
+
+	var resp dto.HealthResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+
+	assert.Equal(t, "fail", resp.Status)
+	require.Len(t, resp.Checks, 2)
+	assert.Equal(t, "pass", resp.Checks["cache"][0].Status)
+	assert.Equal(t, "fail", resp.Checks["db"][0].Status)
+	assert.Equal(t, "down", resp.Checks["db"][0].Output)
+	assert.Equal(t, "ms", resp.Checks["db"][0].ObservedUnit)
+	assert.NotEmpty(t, resp.Checks["db"][0].Time)
+
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/healthz/readiness?verbose=false", nil))
+
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	assert.NotContains(t, w.Body.String(), "checks")
+
+	controller.Drain()
+
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/healthz/readiness", nil))
+
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Equal(t, biz.ErrServiceDraining.Error(), resp.Output)
+}
+
+func TestLivenessReportPass(t *testing.T) {
+	controller := app.NewController()
+	controller.RegisterHealthz("db", func(context.Context) error { return nil })
+
+	mux := newHealthzMux(t, controller)
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/healthz/liveness", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp dto.HealthResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+
+	assert.Equal(t, "pass", resp.Status)
+	assert.Equal(t, "pass", resp.Checks["db"][0].Status)
+}
+
+func TestStartupReport(t *testing.T) {
+	controller := app.NewController()
+	controller.RegisterHealthz("db", func(context.Context) error { return nil }, app.WithStartup(true))
+
+	mux := newHealthzMux(t, controller)
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/healthz/startup", nil))
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+
+	var resp dto.HealthResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	assert.Equal(t, biz.ErrServiceStarting.Error(), resp.Output)
+
+	controller.MarkStarted()
+
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/healthz/startup", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	assert.Equal(t, "pass", resp.Status)
+	assert.Equal(t, "pass", resp.Checks["db"][0].Status)
+}
+
+func TestReadinessSeverity(t *testing.T) {
+	controller := app.NewController()
+	controller.RegisterHealthz("db", func(context.Context) error { return nil })
+	controller.RegisterHealthz("cache", func(context.Context) error { return errDown },
+		app.WithSeverity(app.SeverityDegraded))
+	controller.RegisterHealthz("mail", func(context.Context) error { return errDown },
+		app.WithSeverity(app.SeverityInformational))
+
+	mux := newHealthzMux(t, controller)
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/healthz/readiness", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp dto.HealthResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+
+	assert.Equal(t, "warn", resp.Status)
+	assert.Equal(t, "degraded", resp.Output)
+	assert.Equal(t, "fail", resp.Checks["cache"][0].Status)
+	assert.Equal(t, "degraded", resp.Checks["cache"][0].Severity)
+	assert.Equal(t, "fail", resp.Checks["mail"][0].Status)
+	assert.Equal(t, "critical", resp.Checks["db"][0].Severity)
+
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/healthz/readiness?verbose=false", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
@@ -98,19 +98,35 @@ func (h *placeholder) create(w http.ResponseWriter, r *http.Request) {
 // list implements the endpoint for listing placeholders.
 //
 //	@Summary		List placeholders
-//	@Description	Retrieve a list of all placeholders.
+//	@Description	Retrieve a page of placeholders. Pass the next_cursor of a page as cursor, with the same sort and order, to get the next one.
 //	@Tags			Placeholders
 //	@Accept			json
 //	@Produce		json
-//	@Success		200	{object}	dto.PlaceholderListResponse	"ok"
-//	@Failure		500	{object}	dto.ErrorResponse			"Internal Server Error"
+//	@Param			limit			query		int							false	"Page size"	default(20)	minimum(1)	maximum(100)
+//	@Param			cursor			query		string						false	"next_cursor of the previous page"
+//	@Param			sort			query		string						false	"Sort field"	Enums(created_at, name)	default(created_at)
+//	@Param			order			query		string						false	"Sort order"	Enums(asc, desc)		default(asc)
+//	@Param			name_prefix		query		string						false	"Name starts with"
+//	@Param			name_contains	query		string						false	"Name contains"
+//	@Param			total			query		bool						false	"Count the matching placeholders"
+//	@Success		200				{object}	dto.PlaceholderListResponse	"ok"
+//	@Failure		400				{object}	dto.ErrorResponse			"Bad Request"
+//	@Failure		500				{object}	dto.ErrorResponse			"Internal Server Error"
 //	@Router			/apis/mocks/placeholders [get]
 func (h *placeholder) list(w http.ResponseWriter, r *http.Request) {
 	logger := h.logger.With("method", "List")
 	ctx := r.Context()
 	logger.DebugContext(ctx, "List placeholders")
 
-	placeholders, err := h.placeholder.List(ctx)
+	opts, err := dto.ParseListOptions(r.URL.Query())
+	if err != nil {
+		logger.WarnContext(ctx, "invalid list query", "error", err)
+		dto.HandleError(err, w)
+
+		return
+	}
+
+	page, err := h.placeholder.List(ctx, opts)
 	if err != nil {
 		logger.ErrorContext(ctx, "failed to list placeholders", "error", err)
 		dto.HandleError(err, w)
@@ -121,7 +137,7 @@ func (h *placeholder) list(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 
-	resp := dto.ToPlaceholderResps(placeholders)
+	resp := dto.ToPlaceholderListResponse(page)
 	if err := json.NewEncoder(w).Encode(resp); err != nil {
 		logger.ErrorContext(ctx, "failed to encode response", "error", err)
 		panic(err)
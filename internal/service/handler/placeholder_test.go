@@ -0,0 +1,170 @@
+package handler_test
+
+import (
+	"application/app"
+	"application/internal/biz"
+	"application/internal/entity"
+	"application/internal/mocks"
+	"application/internal/service"
+	"application/internal/service/dto"
+	"application/internal/service/handler"
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/knadh/koanf/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// newAppLogger returns an AppLogger discarding every record.
+func newAppLogger(t *testing.T) *mocks.MockAppLogger {
+	t.Helper()
+
+	appLogger := mocks.NewMockAppLogger(t)
+	appLogger.EXPECT().GetLogger().Return(slog.New(slog.DiscardHandler)).Maybe()
+
+	return appLogger
+}
+
+// startServer serves the placeholder routes with the given server.http settings.
+func startServer(t *testing.T, uc biz.UsecasePlaceholder, settings map[string]any) string {
+	t.Helper()
+
+	ctx := context.Background()
+	logger := slog.New(slog.DiscardHandler)
+
+	mux := http.NewServeMux()
+	require.NoError(t, handler.NewPlaceholder(logger, mux, uc).RegisterHandler(ctx))
+
+	k := koanf.New(".")
+	require.NoError(t, k.Set("server.http.addr", "127.0.0.1:0"))
+
+	for key, value := range settings {
+		require.NoError(t, k.Set("server.http."+key, value))
+	}
+
+	cfg, err := app.NewHTTPServerConfig(ctx, app.NewKConfig(k))
+	require.NoError(t, err)
+
+	controller := app.NewController()
+	servers := app.NewHTTPServers(cfg, mux, service.NewAdminMux(), newAppLogger(t), controller)
+	require.Len(t, servers, 1)
+
+	require.NoError(t, servers[0].Start(ctx))
+	t.Cleanup(func() { _ = servers[0].Shutdown(context.Background()) })
+
+	return "http://" + servers[0].Addr().String()
+}
+
+func h2cClient() *http.Client {
+	protocols := new(http.Protocols)
+	protocols.SetUnencryptedHTTP2(true)
+
+	return &http.Client{Transport: &http.Transport{Protocols: protocols}}
+}
+
+func TestPlaceholderOverH2C(t *testing.T) {
+	first := entity.Placeholder{ID: uuid.New(), Name: "first"}
+
+	uc := mocks.NewMockUsecasePlaceholder(t)
+	uc.EXPECT().Create(mock.Anything, "first").Return(first.ID, nil).Once()
+	uc.EXPECT().List(mock.Anything, mock.Anything).
+		Return(biz.Page[entity.Placeholder]{Items: []entity.Placeholder{first}}, nil).Once()
+	uc.EXPECT().Get(mock.Anything, first.ID).Return(first, nil).Once()
+
+	base := startServer(t, uc, map[string]any{
+		"protocols": []string{app.ProtocolHTTP1, app.ProtocolH2C},
+		"http2":     map[string]any{"max_concurrent_streams": 10},
+	})
+	client := h2cClient()
+
+	body, err := json.Marshal(dto.CreatePlaceholderReq{Name: "first"})
+	require.NoError(t, err)
+
+	resp, err := client.Post(base+"/apis/mocks/placeholders", "application/json", bytes.NewReader(body))
+	require.NoError(t, err)
+
+	defer resp.Body.Close()
+
+	assert.Equal(t, 2, resp.ProtoMajor)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	resp, err = client.Get(base + "/apis/mocks/placeholders")
+	require.NoError(t, err)
+
+	defer resp.Body.Close()
+
+	assert.Equal(t, 2, resp.ProtoMajor)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var list dto.PlaceholderListResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&list))
+	require.Len(t, list.Placeholders, 1)
+	assert.Equal(t, "first", list.Placeholders[0].Name)
+
+	resp, err = client.Get(base + "/apis/mocks/placeholders/" + list.Placeholders[0].ID)
+	require.NoError(t, err)
+
+	defer resp.Body.Close()
+
+	assert.Equal(t, 2, resp.ProtoMajor)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestPlaceholderWithoutH2C(t *testing.T) {
+	uc := mocks.NewMockUsecasePlaceholder(t)
+	uc.EXPECT().List(mock.Anything, mock.Anything).Return(biz.Page[entity.Placeholder]{}, nil).Once()
+
+	base := startServer(t, uc, nil)
+
+	_, err := h2cClient().Get(base + "/apis/mocks/placeholders")
+	require.Error(t, err)
+
+	resp, err := http.Get(base + "/apis/mocks/placeholders")
+	require.NoError(t, err)
+
+	defer resp.Body.Close()
+
+	assert.Equal(t, 1, resp.ProtoMajor)
+}
+
+func TestPlaceholderListQuery(t *testing.T) {
+	uc := mocks.NewMockUsecasePlaceholder(t)
+	uc.EXPECT().List(mock.Anything, biz.ListOptions{
+		Limit:     5,
+		Cursor:    "abc",
+		Sort:      "name",
+		Desc:      true,
+		Filters:   []biz.Filter{{Field: "name", Op: biz.FilterPrefix, Value: "a_b"}},
+		WithTotal: true,
+	}).Return(biz.Page[entity.Placeholder]{NextCursor: "next"}, nil).Once()
+
+	base := startServer(t, uc, nil)
+
+	resp, err := http.Get(base + "/apis/mocks/placeholders?limit=5&cursor=abc&sort=name&order=desc&name_prefix=a_b&total=true")
+	require.NoError(t, err)
+
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var list dto.PlaceholderListResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&list))
+	assert.Equal(t, "next", list.NextCursor)
+	assert.NotNil(t, list.Placeholders, "an empty page lists no placeholders rather than null")
+
+	for _, query := range []string{"limit=ten", "order=up", "total=maybe"} {
+		resp, err := http.Get(base + "/apis/mocks/placeholders?" + query)
+		require.NoError(t, err)
+
+		resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, query)
+	}
+}
@@ -7,7 +7,7 @@ import (
 	"github.com/google/wire"
 )
 
-var ServerProviderSet = wire.NewSet(NewHTTPHandler, http.NewServeMux)
+var ServerProviderSet = wire.NewSet(NewHTTPHandler, http.NewServeMux, NewAdminHandler, NewAdminMux)
 
 // Handler Service Interface.
 type Handler interface {
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS placeholder_name_id_idx;
+DROP INDEX IF EXISTS placeholder_created_at_id_idx;
+ALTER TABLE placeholder ALTER COLUMN created_at DROP NOT NULL;
@@ -0,0 +1,5 @@
+-- keyset pagination of placeholders by created_at or name, then id
+UPDATE placeholder SET created_at = NOW() WHERE created_at IS NULL;
+ALTER TABLE placeholder ALTER COLUMN created_at SET NOT NULL;
+CREATE INDEX placeholder_created_at_id_idx ON placeholder (created_at, id);
+CREATE INDEX placeholder_name_id_idx ON placeholder (name, id);
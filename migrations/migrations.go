@@ -0,0 +1,8 @@
+// Package migrations embeds the SQL migrations, named in the golang-migrate
+// style {version}_{title}.up.sql and {version}_{title}.down.sql.
+package migrations
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS